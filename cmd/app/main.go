@@ -3,26 +3,31 @@ package main
 import (
 	"avito_intership/internal/app"
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 )
 
 func main() {
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGKILL)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
 	defer stop()
 
+	if len(os.Args) > 1 && os.Args[1] == migrateCommand {
+		if err := runMigrate(ctx, os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	a, err := app.New(ctx)
 	if err != nil {
 		panic(err)
 	}
 
-	go func() {
-		if err = a.Run(ctx); err != nil {
-			stop()
-		}
-	}()
-
-	<-ctx.Done()
-	a.Stop()
+	if err = a.Run(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"avito_intership/internal/config"
+	"avito_intership/internal/migrator"
+	"avito_intership/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const migrateCommand = "migrate"
+
+const migrateUsage = `usage: app migrate <command> [version]
+
+commands:
+  up [version]    apply pending migrations, optionally stopping at version
+  down [version]  revert the last migration, or every migration above version, down 0 reverts all
+  a version on the other side of the current one is rejected
+  status          print the current schema version and the embedded migrations`
+
+var (
+	ErrMigrateUsage = errors.New(migrateUsage)
+)
+
+func runMigrate(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 || len(args) > 2 {
+		return ErrMigrateUsage
+	}
+
+	var (
+		version    uint64
+		hasVersion = len(args) == 2
+	)
+	if hasVersion {
+		v, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid version %q\n%w", args[1], ErrMigrateUsage)
+		}
+		version = v
+	}
+
+	//ONLY THE DATABASE SETTINGS ARE READ, SO THE SERVER SECRETS NEED NOT BE SET ON THE HOST THAT MIGRATES
+	cfg, err := config.NewDB()
+	if err != nil {
+		return err
+	}
+
+	m, err := migrator.New(cfg.PostgresConnStr, logger.New())
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch args[0] {
+	case "up":
+		if hasVersion {
+			err = m.UpTo(ctx, uint(version))
+		} else {
+			err = m.Up(ctx)
+		}
+	case "down":
+		if hasVersion {
+			err = m.DownTo(ctx, uint(version))
+		} else {
+			err = m.Steps(ctx, -1)
+		}
+	case "status":
+		if hasVersion {
+			return ErrMigrateUsage
+		}
+	default:
+		return ErrMigrateUsage
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return printMigrationState(m, out)
+}
+
+func printMigrationState(m *migrator.Migrator, out io.Writer) error {
+	state, err := m.State()
+	if err != nil {
+		return err
+	}
+
+	dirty := ""
+	if state.Dirty {
+		dirty = " (dirty)"
+	}
+
+	if _, err = fmt.Fprintf(out, "schema version: %d%s\n", state.Version, dirty); err != nil {
+		return err
+	}
+
+	for _, migration := range state.Migrations {
+		mark := " "
+		if migration.Applied {
+			mark = "x"
+		}
+
+		if _, err = fmt.Fprintf(out, "[%s] %06d_%s\n", mark, migration.Version, migration.Identifier); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
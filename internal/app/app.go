@@ -2,22 +2,42 @@ package app
 
 import (
 	"avito_intership/internal/config"
+	handler_admin_mux_impl "avito_intership/internal/handlers/admin/mux_impl"
+	handler_auth_mux_impl "avito_intership/internal/handlers/auth/mux_impl"
 	handler_bid_mux_impl "avito_intership/internal/handlers/bid/mux_impl"
+	handler_supplier_mux_impl "avito_intership/internal/handlers/supplier/mux_impl"
 	handler_tender_mux_impl "avito_intership/internal/handlers/tender/mux_impl"
+	handler_webhook_mux_impl "avito_intership/internal/handlers/webhook/mux_impl"
+	"avito_intership/internal/metrics"
+	"avito_intership/internal/middlewares"
+	"avito_intership/internal/migrator"
+	"avito_intership/internal/openapi"
+	"avito_intership/internal/outbox"
 	"avito_intership/pkg/logger"
 	"context"
+	"errors"
 	"github.com/gorilla/mux"
 	"golang.org/x/sync/errgroup"
 	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+var errOutboxWebhookSecret = errors.New("OUTBOX_WEBHOOK_SECRET must be set together with OUTBOX_WEBHOOK_URL")
+
 type App struct {
 	sp *serviceProvider
 
 	cfg *config.Config
 
 	router *mux.Router
+	server *http.Server
+
+	dispatcher     *outbox.Dispatcher
+	deliveryWorker *outbox.DeliveryWorker
 
 	logger *slog.Logger
 }
@@ -38,8 +58,63 @@ func (a *App) initConfig(_ context.Context) error {
 	return nil
 }
 
-func (a *App) initMuxHandler(_ context.Context) error {
+func (a *App) initMigrations(ctx context.Context) error {
+	m, err := migrator.New(a.cfg.DB.PostgresConnStr, a.logger)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	return m.Up(ctx)
+}
+
+func (a *App) initMuxHandler(ctx context.Context) error {
+	doc, err := openapi.Load(ctx)
+	if err != nil {
+		a.logger.Error("Failed to load openapi specification", "error", err.Error())
+		return err
+	}
+
+	specRouter, err := openapi.Router(doc)
+	if err != nil {
+		a.logger.Error("Failed to build openapi router", "error", err.Error())
+		return err
+	}
+
 	a.router = mux.NewRouter()
+	a.router.Use(middlewares.Metrics(), middlewares.OpenAPI(specRouter, a.cfg.Debug, a.logger))
+
+	a.router.Path("/api/openapi.json").Methods(http.MethodGet).Handler(openapi.Handler())
+
+	return nil
+}
+
+func (a *App) initMetricsHandler(ctx context.Context) error {
+	db, err := a.sp.DB(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = metrics.RegisterPool(db.Pool()); err != nil {
+		a.logger.Error("Failed to register db pool metrics", "error", err.Error())
+		return err
+	}
+
+	a.router.Path("/metrics").Methods(http.MethodGet).Handler(metrics.Handler())
+
+	return nil
+}
+
+func (a *App) initAuthHandler(ctx context.Context) error {
+	authService, err := a.sp.AuthService(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = handler_auth_mux_impl.Register(a.router, authService, a.logger); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -49,7 +124,17 @@ func (a *App) initBidsHandler(ctx context.Context) error {
 		return err
 	}
 
-	if err = handler_bid_mux_impl.Register(a.router, bidsService, a.logger); err != nil {
+	authService, err := a.sp.AuthService(ctx)
+	if err != nil {
+		return err
+	}
+
+	idempotencyService, err := a.sp.IdempotencyService(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = handler_bid_mux_impl.Register(a.router, bidsService, authService, idempotencyService, a.logger); err != nil {
 		return err
 	}
 
@@ -62,15 +147,163 @@ func (a *App) initTenderHandler(ctx context.Context) error {
 		return err
 	}
 
-	if err = handler_tender_mux_impl.Register(a.router, tenderService, a.logger); err != nil {
+	authService, err := a.sp.AuthService(ctx)
+	if err != nil {
+		return err
+	}
+
+	idempotencyService, err := a.sp.IdempotencyService(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = handler_tender_mux_impl.Register(a.router, tenderService, authService, idempotencyService, a.logger); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *App) initSupplierHandler(ctx context.Context) error {
+	supplierService, err := a.sp.SupplierService(ctx)
+	if err != nil {
+		return err
+	}
+
+	authService, err := a.sp.AuthService(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = handler_supplier_mux_impl.Register(a.router, supplierService, authService, a.logger); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func (a *App) initWebhookHandler(ctx context.Context) error {
+	webhookService, err := a.sp.WebhookService(ctx)
+	if err != nil {
+		return err
+	}
+
+	authService, err := a.sp.AuthService(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = handler_webhook_mux_impl.Register(a.router, webhookService, authService, a.logger); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *App) initAdminHandler(ctx context.Context) error {
+	employeeService, err := a.sp.EmployeeService(ctx)
+	if err != nil {
+		return err
+	}
+
+	organizationService, err := a.sp.OrganizationService(ctx)
+	if err != nil {
+		return err
+	}
+
+	organizationRespService, err := a.sp.OrganizationResponsibleService(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = handler_admin_mux_impl.Register(a.router, employeeService, organizationService, organizationRespService,
+		a.cfg.AdminToken, a.logger); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *App) initOutboxDispatcher(ctx context.Context) error {
+	outboxService, err := a.sp.OutboxService(ctx)
+	if err != nil {
+		return err
+	}
+
+	webhookService, err := a.sp.WebhookService(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg := a.cfg.Outbox
+
+	//SUBSCRIBED ENDPOINTS ALWAYS GET THEIR EVENTS, THE OTHER SINKS ARE OPTIONAL
+	sinks := make([]outbox.Sink, 0, 3)
+	sinks = append(sinks, outbox.NewSubscriptionSink(webhookService))
+	if cfg.LogSink {
+		sinks = append(sinks, outbox.NewLogSink(a.logger))
+	}
+	if cfg.WebhookURL != "" {
+		if cfg.WebhookSecret == "" {
+			a.logger.Error("Outbox webhook requires a signing secret")
+			return errOutboxWebhookSecret
+		}
+
+		client := &http.Client{Timeout: cfg.WebhookTimeout}
+		sinks = append(sinks, outbox.NewWebhookSink(cfg.WebhookURL, cfg.WebhookSecret, client, cfg.WebhookRetries, cfg.WebhookBackoff))
+	}
+
+	a.dispatcher = outbox.NewDispatcher(outboxService, sinks, cfg.PollInterval, cfg.BatchSize, a.logger)
+
+	return nil
+}
+
+func (a *App) initDeliveryWorker(ctx context.Context) error {
+	webhookService, err := a.sp.WebhookService(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg := a.cfg.Webhooks
+
+	client := &http.Client{Timeout: cfg.Timeout}
+	a.deliveryWorker = outbox.NewDeliveryWorker(webhookService, client, cfg.PollInterval, cfg.BatchSize, a.logger)
+
+	return nil
+}
+
+func (a *App) initHttpServer(_ context.Context) error {
+	a.server = &http.Server{
+		Addr:         a.cfg.Address,
+		Handler:      a.router,
+		ReadTimeout:  a.cfg.HTTP.ReadTimeout,
+		WriteTimeout: a.cfg.HTTP.WriteTimeout,
+		IdleTimeout:  a.cfg.HTTP.IdleTimeout,
+	}
+	return nil
+}
+
 func (a *App) initServiceProvider(_ context.Context) error {
-	a.sp = newServiceProvider(a.cfg.DB.PostgresConnStr, a.logger)
+	idempotencyConfig := IdempotencyConfig{
+		TTL:   a.cfg.Idempotency.TTL,
+		Lease: a.cfg.Idempotency.Lease,
+	}
+
+	outboxConfig := OutboxConfig{
+		MaxAttempts:  a.cfg.Outbox.MaxAttempts,
+		RetryBackoff: a.cfg.Outbox.RetryBackoff,
+		Lease:        a.cfg.Outbox.Lease,
+	}
+
+	webhooksConfig := WebhooksConfig{
+		MaxAttempts:  a.cfg.Webhooks.MaxAttempts,
+		RetryBackoff: a.cfg.Webhooks.RetryBackoff,
+		Lease:        a.cfg.Webhooks.Lease,
+		DisableAfter: a.cfg.Webhooks.DisableAfter,
+	}
+
+	a.sp = newServiceProvider(a.cfg.DB.PostgresConnStr, a.cfg.Auth.JWTSecret, a.cfg.Auth.TokenTTL, idempotencyConfig, outboxConfig,
+		webhooksConfig, a.logger)
 	return nil
 }
 
@@ -78,10 +311,19 @@ func (a *App) initDeps(ctx context.Context) error {
 	deps := [...]func(ctx context.Context) error{
 		a.initLogger,
 		a.initConfig,
+		a.initMigrations,
 		a.initServiceProvider,
 		a.initMuxHandler,
+		a.initMetricsHandler,
+		a.initAuthHandler,
 		a.initBidsHandler,
 		a.initTenderHandler,
+		a.initSupplierHandler,
+		a.initWebhookHandler,
+		a.initAdminHandler,
+		a.initOutboxDispatcher,
+		a.initDeliveryWorker,
+		a.initHttpServer,
 	}
 
 	for _, f := range deps {
@@ -94,44 +336,94 @@ func (a *App) initDeps(ctx context.Context) error {
 }
 
 func (a *App) runHttpServer() error {
-	server := &http.Server{
-		Addr:      a.cfg.Address,
-		Handler:   a.router,
-		TLSConfig: nil,
+	a.logger.Info("Starting http server", "address", a.server.Addr)
+
+	if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		a.logger.Error("Failed to serve http", "error", err.Error())
+		return err
 	}
 
-	return server.ListenAndServe()
+	return nil
 }
 
-func (a *App) Run(ctx context.Context) error {
-	g, _ := errgroup.WithContext(ctx)
+// runIdempotencyCleanup deletes the idempotency keys that expired, the table would grow with every keyed request otherwise.
+func (a *App) runIdempotencyCleanup(ctx context.Context) error {
+	idempotencyService, err := a.sp.IdempotencyService(ctx)
+	if err != nil {
+		return err
+	}
 
-	g.Go(a.runHttpServer)
+	ticker := time.NewTicker(a.cfg.Idempotency.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
 
-	if err := g.Wait(); err != nil {
+		deleted, err := idempotencyService.Purge(ctx)
+		if err != nil {
+			a.logger.Error("Failed to purge expired idempotency keys", "error", err.Error())
+			continue
+		}
+		if deleted > 0 {
+			a.logger.Info("Purged expired idempotency keys", "deleted", deleted)
+		}
+	}
+}
+
+// shutdownHttpServer stops accepting connections and waits for in-flight requests
+// until the drain timeout expires.
+func (a *App) shutdownHttpServer() error {
+	a.logger.Info("Shutting down http server", "timeout", a.cfg.HTTP.ShutdownTimeout.String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+
+	if err := a.server.Shutdown(ctx); err != nil {
+		a.logger.Error("Failed to drain http server", "error", err.Error())
 		return err
 	}
+
 	return nil
 }
 
+// Run serves http until ctx is cancelled or a termination signal arrives,
+// then drains the server and releases the connection pools.
+func (a *App) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+	defer stop()
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(a.runHttpServer)
+	g.Go(func() error {
+		return a.dispatcher.Run(gCtx)
+	})
+	g.Go(func() error {
+		return a.deliveryWorker.Run(gCtx)
+	})
+	g.Go(func() error {
+		return a.runIdempotencyCleanup(gCtx)
+	})
+	g.Go(func() error {
+		<-gCtx.Done()
+		return a.shutdownHttpServer()
+	})
+
+	err := g.Wait()
+	a.Stop()
+
+	return err
+}
+
 func (a *App) Stop() {
-	if a.sp.feedbackService != nil {
-		a.sp.feedbackRepository.CloseConn()
-	}
-	if a.sp.decisionRepository != nil {
-		a.sp.decisionRepository.CloseConn()
-	}
-	if a.sp.employeeRepository != nil {
-		a.sp.employeeRepository.CloseConn()
-	}
-	if a.sp.organizationResponsibleRepository != nil {
-		a.sp.organizationResponsibleRepository.CloseConn()
-	}
-	if a.sp.tendersRepository != nil {
-		a.sp.tendersRepository.CloseConn()
-	}
-	if a.sp.bidRepository != nil {
-		a.sp.bidRepository.CloseConn()
+	if a.sp.db != nil {
+		a.logger.Info("Closing db pool")
+		a.sp.db.Close()
+		a.sp.db = nil
 	}
 }
 
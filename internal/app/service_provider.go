@@ -1,6 +1,10 @@
 package app
 
 import (
+	"avito_intership/internal/auth"
+	"avito_intership/internal/authz"
+	"avito_intership/internal/quorum"
+	"avito_intership/internal/repository"
 	repository_bid "avito_intership/internal/repository/bid"
 	repository_bid_postgres "avito_intership/internal/repository/bid/postgres"
 	repository_decision "avito_intership/internal/repository/decision"
@@ -9,10 +13,22 @@ import (
 	repository_employee_postgres "avito_intership/internal/repository/employee/postgres"
 	repository_feedback "avito_intership/internal/repository/feedback"
 	repository_feedback_postgres "avito_intership/internal/repository/feedback/postgres"
+	repository_idempotency "avito_intership/internal/repository/idempotency"
+	repository_idempotency_postgres "avito_intership/internal/repository/idempotency/postgres"
+	repository_organization "avito_intership/internal/repository/organization"
+	repository_organization_postgres "avito_intership/internal/repository/organization/postgres"
 	repository_organization_resp "avito_intership/internal/repository/organization_responsible"
 	repository_organization_resp_postgres "avito_intership/internal/repository/organization_responsible/postgres"
+	repository_outbox "avito_intership/internal/repository/outbox"
+	repository_outbox_postgres "avito_intership/internal/repository/outbox/postgres"
+	repository_supplier "avito_intership/internal/repository/supplier"
+	repository_supplier_postgres "avito_intership/internal/repository/supplier/postgres"
 	repository_tenders "avito_intership/internal/repository/tender"
 	repository_tenders_postgres "avito_intership/internal/repository/tender/postgres"
+	repository_webhook "avito_intership/internal/repository/webhook"
+	repository_webhook_postgres "avito_intership/internal/repository/webhook/postgres"
+	service_auth "avito_intership/internal/service/auth"
+	service_auth_impl "avito_intership/internal/service/auth/implementation"
 	service_bids "avito_intership/internal/service/bid"
 	service_bids_impl "avito_intership/internal/service/bid/implementation"
 	service_decision "avito_intership/internal/service/decision"
@@ -21,15 +37,31 @@ import (
 	service_employee_impl "avito_intership/internal/service/employee/implementation"
 	service_feedback "avito_intership/internal/service/feedback"
 	service_feedback_impl "avito_intership/internal/service/feedback/implementation"
+	service_idempotency "avito_intership/internal/service/idempotency"
+	service_idempotency_impl "avito_intership/internal/service/idempotency/implementation"
+	service_organization "avito_intership/internal/service/organization"
+	service_organization_impl "avito_intership/internal/service/organization/implementation"
 	service_organization_resp "avito_intership/internal/service/organization_responsible"
 	service_organization_resp_impl "avito_intership/internal/service/organization_responsible/implementation"
+	service_outbox "avito_intership/internal/service/outbox"
+	service_outbox_impl "avito_intership/internal/service/outbox/implementation"
+	service_supplier "avito_intership/internal/service/supplier"
+	service_supplier_impl "avito_intership/internal/service/supplier/implementation"
 	service_tenders "avito_intership/internal/service/tender"
 	service_tenders_impl "avito_intership/internal/service/tender/implementation"
+	service_webhook "avito_intership/internal/service/webhook"
+	service_webhook_impl "avito_intership/internal/service/webhook/implementation"
+	"avito_intership/pkg/postgres"
 	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"log/slog"
+	"time"
 )
 
 type serviceProvider struct {
+	db        *postgres.DB
+	txManager postgres.TxManager
+
 	feedbackRepository repository_feedback.Repository
 	feedbackService    service_feedback.Service
 
@@ -39,27 +71,107 @@ type serviceProvider struct {
 	employeeRepository repository_employee.Repository
 	employeeService    service_employee.Service
 
+	organizationRepository repository_organization.Repository
+	organizationService    service_organization.Service
+
 	organizationResponsibleRepository repository_organization_resp.Repository
 	organizationResponsibleService    service_organization_resp.Service
 
 	tendersRepository repository_tenders.Repository
 	tendersService    service_tenders.Service
+	decisionRules     *quorum.Registry
 
 	bidRepository repository_bid.Repository
 	bidService    service_bids.Service
 
+	policy authz.Policy
+
+	idempotencyRepository repository_idempotency.Repository
+	idempotencyService    service_idempotency.Service
+
+	outboxRepository repository_outbox.Repository
+	outboxService    service_outbox.Service
+
+	supplierRepository repository_supplier.Repository
+	supplierService    service_supplier.Service
+
+	webhookRepository repository_webhook.Repository
+	webhookService    service_webhook.Service
+
+	tokenManager *auth.TokenManager
+	authService  service_auth.Service
+
 	DBConnectionStr string
+	JWTSecret       string
+	TokenTTL        time.Duration
+	Idempotency     IdempotencyConfig
+	Outbox          OutboxConfig
+	Webhooks        WebhooksConfig
 	logger          *slog.Logger
 }
 
+// IdempotencyConfig tells how long a response is replayed and how long a request that has not finished holds its key.
+type IdempotencyConfig struct {
+	TTL   time.Duration
+	Lease time.Duration
+}
+
+// OutboxConfig tells how failed event deliveries are retried.
+type OutboxConfig struct {
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	Lease        time.Duration
+}
+
+// WebhooksConfig tells how failed deliveries to subscribed endpoints are retried and when an endpoint is given up on.
+type WebhooksConfig struct {
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	Lease        time.Duration
+	DisableAfter int
+}
+
+func (sp *serviceProvider) DB(ctx context.Context) (*postgres.DB, error) {
+	if sp.db == nil {
+		pool, err := pgxpool.New(ctx, sp.DBConnectionStr)
+		if err != nil {
+			sp.logger.Error("Failed to open connection to db", "error", err.Error())
+			return nil, repository.ErrOpenConn
+		}
+
+		if err = pool.Ping(ctx); err != nil {
+			pool.Close()
+			sp.logger.Error("Failed to ping db", "error", err.Error())
+			return nil, repository.ErrPingDB
+		}
+
+		sp.db = postgres.New(pool)
+	}
+
+	return sp.db, nil
+}
+
+func (sp *serviceProvider) TxManager(ctx context.Context) (postgres.TxManager, error) {
+	if sp.txManager == nil {
+		db, err := sp.DB(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.txManager = postgres.NewTxManager(db)
+	}
+
+	return sp.txManager, nil
+}
+
 func (sp *serviceProvider) FeedbackRepository(ctx context.Context) (repository_feedback.Repository, error) {
 	if sp.feedbackRepository == nil {
-		repository, err := repository_feedback_postgres.New(ctx, sp.DBConnectionStr, sp.logger)
+		db, err := sp.DB(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		sp.feedbackRepository = repository
+		sp.feedbackRepository = repository_feedback_postgres.New(db, sp.logger)
 	}
 	return sp.feedbackRepository, nil
 }
@@ -79,12 +191,12 @@ func (sp *serviceProvider) FeedbackService(ctx context.Context) (service_feedbac
 
 func (sp *serviceProvider) DecisionRepository(ctx context.Context) (repository_decision.Repository, error) {
 	if sp.decisionRepository == nil {
-		repository, err := repository_decision_postgres.New(ctx, sp.DBConnectionStr, sp.logger)
+		db, err := sp.DB(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		sp.decisionRepository = repository
+		sp.decisionRepository = repository_decision_postgres.New(db, sp.logger)
 	}
 	return sp.decisionRepository, nil
 }
@@ -103,12 +215,12 @@ func (sp *serviceProvider) DecisionService(ctx context.Context) (service_decisio
 
 func (sp *serviceProvider) EmployeeRepository(ctx context.Context) (repository_employee.Repository, error) {
 	if sp.employeeRepository == nil {
-		repository, err := repository_employee_postgres.New(ctx, sp.DBConnectionStr, sp.logger)
+		db, err := sp.DB(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		sp.employeeRepository = repository
+		sp.employeeRepository = repository_employee_postgres.New(db, sp.logger)
 	}
 	return sp.employeeRepository, nil
 }
@@ -126,14 +238,39 @@ func (sp *serviceProvider) EmployeeService(ctx context.Context) (service_employe
 	return sp.employeeService, nil
 }
 
+func (sp *serviceProvider) OrganizationRepository(ctx context.Context) (repository_organization.Repository, error) {
+	if sp.organizationRepository == nil {
+		db, err := sp.DB(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.organizationRepository = repository_organization_postgres.New(db, sp.logger)
+	}
+	return sp.organizationRepository, nil
+}
+
+func (sp *serviceProvider) OrganizationService(ctx context.Context) (service_organization.Service, error) {
+	if sp.organizationService == nil {
+		repository, err := sp.OrganizationRepository(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.organizationService = service_organization_impl.New(repository, sp.logger)
+	}
+
+	return sp.organizationService, nil
+}
+
 func (sp *serviceProvider) OrganizationResponsibleRepository(ctx context.Context) (repository_organization_resp.Repository, error) {
 	if sp.organizationResponsibleRepository == nil {
-		repository, err := repository_organization_resp_postgres.New(ctx, sp.DBConnectionStr, sp.logger)
+		db, err := sp.DB(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		sp.organizationResponsibleRepository = repository
+		sp.organizationResponsibleRepository = repository_organization_resp_postgres.New(db, sp.logger)
 	}
 	return sp.organizationResponsibleRepository, nil
 }
@@ -145,7 +282,23 @@ func (sp *serviceProvider) OrganizationResponsibleService(ctx context.Context) (
 			return nil, err
 		}
 
-		sp.organizationResponsibleService = service_organization_resp_impl.New(repository, sp.logger)
+		organizationService, err := sp.OrganizationService(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		employeeService, err := sp.EmployeeService(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		txManager, err := sp.TxManager(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.organizationResponsibleService = service_organization_resp_impl.New(repository, txManager, organizationService, employeeService,
+			sp.Settler(), sp.logger)
 	}
 
 	return sp.organizationResponsibleService, nil
@@ -153,12 +306,12 @@ func (sp *serviceProvider) OrganizationResponsibleService(ctx context.Context) (
 
 func (sp *serviceProvider) TenderRepository(ctx context.Context) (repository_tenders.Repository, error) {
 	if sp.tendersRepository == nil {
-		repository, err := repository_tenders_postgres.New(ctx, sp.DBConnectionStr, sp.logger)
+		db, err := sp.DB(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		sp.tendersRepository = repository
+		sp.tendersRepository = repository_tenders_postgres.New(db, sp.logger)
 	}
 
 	return sp.tendersRepository, nil
@@ -171,30 +324,62 @@ func (sp *serviceProvider) TenderService(ctx context.Context) (service_tenders.S
 			return nil, err
 		}
 
-		employeeService, err := sp.EmployeeService(ctx)
+		txManager, err := sp.TxManager(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		organizationRespService, err := sp.OrganizationResponsibleService(ctx)
+		policy, err := sp.Policy(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		sp.tendersService = service_tenders_impl.New(repository, employeeService, organizationRespService, sp.logger)
+		organizationResponsibleService, err := sp.OrganizationResponsibleService(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		outboxService, err := sp.OutboxService(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.tendersService = service_tenders_impl.New(repository, txManager, policy, organizationResponsibleService, outboxService, sp.DecisionRules(),
+			sp.Settler(), sp.logger)
 	}
 
 	return sp.tendersService, nil
 }
 
+// Settler settles pending bids through the bid service. The bid service depends on the services that settle,
+// so it is resolved when bids are settled rather than here
+func (sp *serviceProvider) Settler() quorum.Settler {
+	return quorum.SettlerFunc(func(ctx context.Context, organizationIDs []string) error {
+		bidService, err := sp.BidService(ctx)
+		if err != nil {
+			return err
+		}
+		return bidService.SettleOpenBids(ctx, organizationIDs)
+	})
+}
+
+// DecisionRules registers the rules behind the decision policies of tenders
+func (sp *serviceProvider) DecisionRules() *quorum.Registry {
+	if sp.decisionRules == nil {
+		sp.decisionRules = quorum.New()
+	}
+
+	return sp.decisionRules
+}
+
 func (sp *serviceProvider) BidRepository(ctx context.Context) (repository_bid.Repository, error) {
 	if sp.bidRepository == nil {
-		repository, err := repository_bid_postgres.New(ctx, sp.DBConnectionStr, sp.logger)
+		db, err := sp.DB(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		sp.bidRepository = repository
+		sp.bidRepository = repository_bid_postgres.New(db, sp.logger)
 	}
 	return sp.bidRepository, nil
 }
@@ -231,14 +416,195 @@ func (sp *serviceProvider) BidService(ctx context.Context) (service_bids.Service
 			return nil, err
 		}
 
-		sp.bidService = service_bids_impl.New(repository, employeeService, organizationResponsibleService, tenderService, decisionService, feedbackService, sp.logger)
+		txManager, err := sp.TxManager(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		policy, err := sp.Policy(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		outboxService, err := sp.OutboxService(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.bidService = service_bids_impl.New(repository, txManager, policy, employeeService, organizationResponsibleService, tenderService, decisionService, feedbackService, outboxService,
+			sp.DecisionRules(), sp.logger)
 	}
 	return sp.bidService, nil
 }
 
-func newServiceProvider(DBConnectionStr string, logger *slog.Logger) *serviceProvider {
+func (sp *serviceProvider) Policy(ctx context.Context) (authz.Policy, error) {
+	if sp.policy == nil {
+		tendersRepository, err := sp.TenderRepository(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		bidRepository, err := sp.BidRepository(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.policy = authz.New(tendersRepository, bidRepository, sp.logger)
+	}
+
+	return sp.policy, nil
+}
+
+func (sp *serviceProvider) IdempotencyRepository(ctx context.Context) (repository_idempotency.Repository, error) {
+	if sp.idempotencyRepository == nil {
+		db, err := sp.DB(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.idempotencyRepository = repository_idempotency_postgres.New(db, sp.logger)
+	}
+
+	return sp.idempotencyRepository, nil
+}
+
+func (sp *serviceProvider) IdempotencyService(ctx context.Context) (service_idempotency.Service, error) {
+	if sp.idempotencyService == nil {
+		repository, err := sp.IdempotencyRepository(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.idempotencyService = service_idempotency_impl.New(repository, sp.Idempotency.TTL, sp.Idempotency.Lease, sp.logger)
+	}
+
+	return sp.idempotencyService, nil
+}
+
+func (sp *serviceProvider) OutboxRepository(ctx context.Context) (repository_outbox.Repository, error) {
+	if sp.outboxRepository == nil {
+		db, err := sp.DB(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.outboxRepository = repository_outbox_postgres.New(db, sp.logger)
+	}
+
+	return sp.outboxRepository, nil
+}
+
+func (sp *serviceProvider) OutboxService(ctx context.Context) (service_outbox.Service, error) {
+	if sp.outboxService == nil {
+		repository, err := sp.OutboxRepository(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.outboxService = service_outbox_impl.New(repository, sp.Outbox.MaxAttempts, sp.Outbox.RetryBackoff, sp.Outbox.Lease, sp.logger)
+	}
+
+	return sp.outboxService, nil
+}
+
+func (sp *serviceProvider) SupplierRepository(ctx context.Context) (repository_supplier.Repository, error) {
+	if sp.supplierRepository == nil {
+		db, err := sp.DB(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.supplierRepository = repository_supplier_postgres.New(db, sp.logger)
+	}
+
+	return sp.supplierRepository, nil
+}
+
+func (sp *serviceProvider) SupplierService(ctx context.Context) (service_supplier.Service, error) {
+	if sp.supplierService == nil {
+		repository, err := sp.SupplierRepository(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		policy, err := sp.Policy(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		feedbackService, err := sp.FeedbackService(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.supplierService = service_supplier_impl.New(repository, policy, feedbackService, sp.logger)
+	}
+
+	return sp.supplierService, nil
+}
+
+func (sp *serviceProvider) WebhookRepository(ctx context.Context) (repository_webhook.Repository, error) {
+	if sp.webhookRepository == nil {
+		db, err := sp.DB(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.webhookRepository = repository_webhook_postgres.New(db, sp.logger)
+	}
+
+	return sp.webhookRepository, nil
+}
+
+func (sp *serviceProvider) WebhookService(ctx context.Context) (service_webhook.Service, error) {
+	if sp.webhookService == nil {
+		repository, err := sp.WebhookRepository(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.webhookService = service_webhook_impl.New(repository, sp.Webhooks.MaxAttempts, sp.Webhooks.RetryBackoff, sp.Webhooks.Lease,
+			sp.Webhooks.DisableAfter, sp.logger)
+	}
+
+	return sp.webhookService, nil
+}
+
+func (sp *serviceProvider) TokenManager() *auth.TokenManager {
+	if sp.tokenManager == nil {
+		sp.tokenManager = auth.NewTokenManager(sp.JWTSecret, sp.TokenTTL)
+	}
+
+	return sp.tokenManager
+}
+
+func (sp *serviceProvider) AuthService(ctx context.Context) (service_auth.Service, error) {
+	if sp.authService == nil {
+		employeeService, err := sp.EmployeeService(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		organizationResponsibleService, err := sp.OrganizationResponsibleService(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sp.authService = service_auth_impl.New(sp.TokenManager(), employeeService, organizationResponsibleService, sp.logger)
+	}
+
+	return sp.authService, nil
+}
+
+func newServiceProvider(DBConnectionStr, JWTSecret string, TokenTTL time.Duration, Idempotency IdempotencyConfig, Outbox OutboxConfig,
+	Webhooks WebhooksConfig, logger *slog.Logger) *serviceProvider {
 	sp := &serviceProvider{
 		DBConnectionStr: DBConnectionStr,
+		JWTSecret:       JWTSecret,
+		TokenTTL:        TokenTTL,
+		Idempotency:     Idempotency,
+		Outbox:          Outbox,
+		Webhooks:        Webhooks,
 		logger:          logger,
 	}
 	return sp
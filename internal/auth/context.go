@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+type identityKey struct{}
+
+// Identity describes the authenticated caller of a request.
+// OrganizationIDs lists every organization the caller represents, it is empty for users without one.
+type Identity struct {
+	EmployeeID      string
+	Username        string
+	OrganizationIDs []string
+}
+
+// MemberOf reports whether the caller represents the organization.
+func (i Identity) MemberOf(organizationID string) bool {
+	return organizationID != "" && slices.Contains(i.OrganizationIDs, organizationID)
+}
+
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
@@ -0,0 +1,9 @@
+package auth
+
+import "errors"
+
+var (
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrInvalidToken    = errors.New("invalid token")
+	ErrInternal        = errors.New("internal error")
+)
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// Issue signs an HS256 token whose subject is the employee id.
+func (m *TokenManager) Issue(employeeID string) (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Subject:   employeeID,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(m.ttl)),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", ErrInternal
+	}
+
+	return token, nil
+}
+
+// Parse validates the signature and expiration of the token and returns its subject.
+func (m *TokenManager) Parse(token string) (employeeID string, err error) {
+	claims := jwt.RegisteredClaims{}
+
+	_, err = jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return m.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || claims.Subject == "" {
+		return "", ErrInvalidToken
+	}
+
+	return claims.Subject, nil
+}
+
+func NewTokenManager(secret string, ttl time.Duration) *TokenManager {
+	return &TokenManager{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
@@ -0,0 +1,31 @@
+package authz
+
+import (
+	"avito_intership/internal/model"
+	"context"
+)
+
+// Policy answers whether the caller stored in the context may perform an action. Every check returns nil,
+// ErrUnauthenticated, ErrForbidden or ErrNotFound, CanDecideOnBid also ErrNotOpen. Resources the caller is not allowed to see at all are
+// reported as ErrNotFound, so their existence does not leak.
+type Policy interface {
+	//CanViewTender allows everyone to see published and closed tenders, drafts are visible to the owning organization only
+	CanViewTender(ctx context.Context, tenderID string) error
+	//CanManageTender allows members of the owning organization to edit, publish, close and roll back the tender and to read its bids
+	CanManageTender(ctx context.Context, tenderID string) error
+	//CanCreateTender allows an organization member to create tenders on its behalf, the organization must be named explicitly
+	CanCreateTender(ctx context.Context, organizationID string) error
+	//CanBidAs allows a user to bid on its own behalf or on behalf of any organization it represents
+	CanBidAs(ctx context.Context, authorType string, authorID string) error
+	//CanViewBid allows the bid author and the organization that launched the tender. Drafts are visible to the author only
+	CanViewBid(ctx context.Context, bidID string) error
+	//CanEditBid allows the bid author to edit, publish, cancel and roll back the bid
+	CanEditBid(ctx context.Context, bidID string) error
+	//CanDecideOnBid allows the organization that launched the tender to decide on a published bid. A canceled bid is not open
+	CanDecideOnBid(ctx context.Context, bidID string) error
+	//CanReviewBid allows the organization that launched the tender to review a bid that is no longer a draft
+	CanReviewBid(ctx context.Context, bidID string) error
+	//CanReadReviews allows the author itself and the organizations that launched a tender the author bid on to read
+	//the reviews on its bids, the same audience the reviews of a tender are shown to
+	CanReadReviews(ctx context.Context, author model.BidAuthor) error
+}
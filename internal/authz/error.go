@@ -0,0 +1,11 @@
+package authz
+
+import "errors"
+
+var (
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrForbidden       = errors.New("forbidden")
+	ErrNotFound        = errors.New("not found")
+	ErrNotOpen         = errors.New("not open for decisions")
+	ErrInternal        = errors.New("internal error")
+)
@@ -0,0 +1,273 @@
+package authz
+
+import (
+	"avito_intership/internal/auth"
+	"avito_intership/internal/model"
+	repository_bid "avito_intership/internal/repository/bid"
+	repository_tenders "avito_intership/internal/repository/tender"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+)
+
+var (
+	authorTypeUser         = "User"
+	authorTypeOrganization = "Organization"
+)
+
+type policy struct {
+	tendersRepository repository_tenders.Repository
+	bidsRepository    repository_bid.Repository
+
+	logger *slog.Logger
+}
+
+func (p *policy) caller(ctx context.Context) (auth.Identity, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return auth.Identity{}, ErrUnauthenticated
+	}
+
+	return identity, nil
+}
+
+func (p *policy) tender(ctx context.Context, tenderID string) (organizationID string, status string, err error) {
+	organizationID, status, err = p.tendersRepository.TenderStatus(ctx, tenderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_tenders.ErrNoTenders):
+			return "", "", ErrNotFound
+		default:
+			return "", "", ErrInternal
+		}
+	}
+
+	return organizationID, status, nil
+}
+
+func (p *policy) bid(ctx context.Context, bidID string) (status string, tenderID string, authorID string, err error) {
+	status, tenderID, authorID, err = p.bidsRepository.GetStatus(ctx, bidID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_bid.ErrNoBids):
+			return "", "", "", ErrNotFound
+		default:
+			return "", "", "", ErrInternal
+		}
+	}
+
+	return status, tenderID, authorID, nil
+}
+
+func member(identity auth.Identity, organizationID string) bool {
+	return identity.MemberOf(organizationID)
+}
+
+func author(identity auth.Identity, authorID string) bool {
+	return authorID == identity.EmployeeID || member(identity, authorID)
+}
+
+func (p *policy) CanViewTender(ctx context.Context, tenderID string) error {
+	organizationID, status, err := p.tender(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	if status != model.TenderStatusCreated {
+		return nil
+	}
+
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok || !member(identity, organizationID) {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (p *policy) CanManageTender(ctx context.Context, tenderID string) error {
+	identity, err := p.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	organizationID, status, err := p.tender(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case member(identity, organizationID):
+		return nil
+	case status == model.TenderStatusCreated:
+		return ErrNotFound
+	default:
+		return ErrForbidden
+	}
+}
+
+func (p *policy) CanCreateTender(ctx context.Context, organizationID string) error {
+	identity, err := p.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !member(identity, organizationID) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func (p *policy) CanBidAs(ctx context.Context, authorType string, authorID string) error {
+	identity, err := p.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case strings.EqualFold(authorType, authorTypeUser) && authorID == identity.EmployeeID:
+		return nil
+	case strings.EqualFold(authorType, authorTypeOrganization) && member(identity, authorID):
+		return nil
+	default:
+		return ErrForbidden
+	}
+}
+
+func (p *policy) CanViewBid(ctx context.Context, bidID string) error {
+	identity, err := p.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, tenderID, authorID, err := p.bid(ctx, bidID)
+	if err != nil {
+		return err
+	}
+
+	if author(identity, authorID) {
+		return nil
+	}
+
+	if status == model.BidStatusCreated {
+		return ErrNotFound
+	}
+
+	tenderOrganizationID, _, err := p.tender(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	if !member(identity, tenderOrganizationID) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func (p *policy) CanEditBid(ctx context.Context, bidID string) error {
+	identity, err := p.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, _, authorID, err := p.bid(ctx, bidID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case author(identity, authorID):
+		return nil
+	case status == model.BidStatusCreated:
+		return ErrNotFound
+	default:
+		return ErrForbidden
+	}
+}
+
+func (p *policy) CanDecideOnBid(ctx context.Context, bidID string) error {
+	status, err := p.tenderOrganizationBid(ctx, bidID)
+	if err != nil {
+		return err
+	}
+
+	//A CANCELED BID IS WITHDRAWN FROM THE TENDER, IT CAN NOT WIN IT
+	if status != model.BidStatusPublished {
+		return ErrNotOpen
+	}
+
+	return nil
+}
+
+func (p *policy) CanReviewBid(ctx context.Context, bidID string) error {
+	_, err := p.tenderOrganizationBid(ctx, bidID)
+	return err
+}
+
+// tenderOrganizationBid allows the organization that launched the tender to reach a bid that is no longer a draft
+// and returns the bid status
+func (p *policy) tenderOrganizationBid(ctx context.Context, bidID string) (string, error) {
+	identity, err := p.caller(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	status, tenderID, authorID, err := p.bid(ctx, bidID)
+	if err != nil {
+		return "", err
+	}
+
+	//DRAFTS ARE NOT OPEN TO THE TENDER ORGANIZATION, AND OUTSIDERS CAN NOT EVEN SEE THEM
+	if status == model.BidStatusCreated {
+		if author(identity, authorID) {
+			return "", ErrForbidden
+		}
+		return "", ErrNotFound
+	}
+
+	tenderOrganizationID, _, err := p.tender(ctx, tenderID)
+	if err != nil {
+		return "", err
+	}
+
+	if !member(identity, tenderOrganizationID) {
+		return "", ErrForbidden
+	}
+
+	return status, nil
+}
+
+func (p *policy) CanReadReviews(ctx context.Context, bidAuthor model.BidAuthor) error {
+	identity, err := p.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if author(identity, bidAuthor.ID) {
+		return nil
+	}
+
+	bidOnCaller, err := p.bidsRepository.AuthorBidOnOrganizations(ctx, []model.BidAuthor{bidAuthor}, identity.OrganizationIDs)
+	if err != nil {
+		return ErrInternal
+	}
+
+	if !bidOnCaller {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func New(tendersRepository repository_tenders.Repository, bidsRepository repository_bid.Repository, logger *slog.Logger) Policy {
+	p := &policy{
+		tendersRepository: tendersRepository,
+		bidsRepository:    bidsRepository,
+		logger:            logger,
+	}
+
+	return p
+}
@@ -0,0 +1,298 @@
+package authz_test
+
+import (
+	"avito_intership/internal/auth"
+	"avito_intership/internal/authz"
+	"avito_intership/internal/model"
+	repository_bid "avito_intership/internal/repository/bid"
+	repository_tenders "avito_intership/internal/repository/tender"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+const (
+	tenderOrganizationID = "tender-organization"
+	bidderOrganizationID = "bidder-organization"
+	otherOrganizationID  = "other-organization"
+
+	tenderID        = "tender"
+	missingTenderID = "missing-tender"
+	bidID           = "bid"
+	missingBidID    = "missing-bid"
+
+	bidderID = "bidder"
+)
+
+// tenders serves the status of a single tender, the rest of the repository is not used by the policy
+type tenders struct {
+	repository_tenders.Repository
+	status string
+}
+
+func (r tenders) TenderStatus(_ context.Context, id string) (string, string, error) {
+	if id != tenderID {
+		return "", "", repository_tenders.ErrNoTenders
+	}
+	return tenderOrganizationID, r.status, nil
+}
+
+// bids serves a single bid on the tender, authored by authorID
+type bids struct {
+	repository_bid.Repository
+	status   string
+	authorID string
+}
+
+func (r bids) GetStatus(_ context.Context, id string) (string, string, string, error) {
+	if id != bidID {
+		return "", "", "", repository_bid.ErrNoBids
+	}
+	return r.status, tenderID, r.authorID, nil
+}
+
+func (r bids) AuthorBidOnOrganizations(_ context.Context, authors []model.BidAuthor, organizationIDs []string) (bool, error) {
+	for _, author := range authors {
+		if author.ID == r.authorID && r.status != model.BidStatusCreated && slices.Contains(organizationIDs, tenderOrganizationID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type caller int
+
+const (
+	anonymous caller = iota
+	outsider
+	tenderMember
+	bidAuthor
+	bidderMember
+)
+
+func (c caller) String() string {
+	return [...]string{"anonymous", "outsider", "tender member", "bid author", "bidder member"}[c]
+}
+
+func (c caller) context() context.Context {
+	ctx := context.Background()
+
+	switch c {
+	case outsider:
+		return auth.WithIdentity(ctx, auth.Identity{EmployeeID: "outsider", OrganizationIDs: []string{otherOrganizationID}})
+	case tenderMember:
+		return auth.WithIdentity(ctx, auth.Identity{EmployeeID: "member", OrganizationIDs: []string{otherOrganizationID, tenderOrganizationID}})
+	case bidAuthor:
+		return auth.WithIdentity(ctx, auth.Identity{EmployeeID: bidderID})
+	case bidderMember:
+		return auth.WithIdentity(ctx, auth.Identity{EmployeeID: "colleague", OrganizationIDs: []string{bidderOrganizationID}})
+	default:
+		return ctx
+	}
+}
+
+func newPolicy(tenderStatus, bidStatus, bidAuthorID string) authz.Policy {
+	return authz.New(tenders{status: tenderStatus}, bids{status: bidStatus, authorID: bidAuthorID}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func check(t *testing.T, name string, got error, want error) {
+	t.Helper()
+
+	if want == nil && got != nil || want != nil && !errors.Is(got, want) {
+		t.Errorf("%s: got %v, want %v", name, got, want)
+	}
+}
+
+func TestTenderAccess(t *testing.T) {
+	type action string
+	const (
+		view   action = "view"
+		manage action = "manage"
+	)
+
+	tests := []struct {
+		caller caller
+		status string
+		action action
+		want   error
+	}{
+		{anonymous, model.TenderStatusCreated, view, authz.ErrNotFound},
+		{anonymous, model.TenderStatusPublished, view, nil},
+		{anonymous, model.TenderStatusClosed, view, nil},
+		{anonymous, model.TenderStatusCreated, manage, authz.ErrUnauthenticated},
+		{anonymous, model.TenderStatusPublished, manage, authz.ErrUnauthenticated},
+		{anonymous, model.TenderStatusClosed, manage, authz.ErrUnauthenticated},
+
+		{outsider, model.TenderStatusCreated, view, authz.ErrNotFound},
+		{outsider, model.TenderStatusPublished, view, nil},
+		{outsider, model.TenderStatusClosed, view, nil},
+		{outsider, model.TenderStatusCreated, manage, authz.ErrNotFound},
+		{outsider, model.TenderStatusPublished, manage, authz.ErrForbidden},
+		{outsider, model.TenderStatusClosed, manage, authz.ErrForbidden},
+
+		{tenderMember, model.TenderStatusCreated, view, nil},
+		{tenderMember, model.TenderStatusPublished, view, nil},
+		{tenderMember, model.TenderStatusClosed, view, nil},
+		{tenderMember, model.TenderStatusCreated, manage, nil},
+		{tenderMember, model.TenderStatusPublished, manage, nil},
+		{tenderMember, model.TenderStatusClosed, manage, nil},
+	}
+
+	for _, tt := range tests {
+		policy := newPolicy(tt.status, model.BidStatusPublished, bidderID)
+
+		var got error
+		switch tt.action {
+		case view:
+			got = policy.CanViewTender(tt.caller.context(), tenderID)
+		case manage:
+			got = policy.CanManageTender(tt.caller.context(), tenderID)
+		}
+
+		check(t, tt.caller.String()+" "+string(tt.action)+" "+tt.status+" tender", got, tt.want)
+	}
+
+	//MISSING TENDERS ARE REPORTED THE SAME WAY AS HIDDEN ONES
+	policy := newPolicy(model.TenderStatusPublished, model.BidStatusPublished, bidderID)
+	check(t, "view missing tender", policy.CanViewTender(tenderMember.context(), missingTenderID), authz.ErrNotFound)
+	check(t, "manage missing tender", policy.CanManageTender(tenderMember.context(), missingTenderID), authz.ErrNotFound)
+}
+
+func TestCreateTender(t *testing.T) {
+	tests := []struct {
+		caller caller
+		want   error
+	}{
+		{anonymous, authz.ErrUnauthenticated},
+		{outsider, authz.ErrForbidden},
+		{bidderMember, authz.ErrForbidden},
+		{tenderMember, nil},
+	}
+
+	policy := newPolicy(model.TenderStatusPublished, model.BidStatusPublished, bidderID)
+	for _, tt := range tests {
+		check(t, tt.caller.String()+" create tender", policy.CanCreateTender(tt.caller.context(), tenderOrganizationID), tt.want)
+	}
+}
+
+func TestBidAs(t *testing.T) {
+	tests := []struct {
+		caller     caller
+		authorType string
+		authorID   string
+		want       error
+	}{
+		{anonymous, "User", bidderID, authz.ErrUnauthenticated},
+		{bidAuthor, "User", bidderID, nil},
+		{bidAuthor, "Organization", bidderOrganizationID, authz.ErrForbidden},
+		{bidderMember, "Organization", bidderOrganizationID, nil},
+		{bidderMember, "User", bidderID, authz.ErrForbidden},
+		{outsider, "Organization", bidderOrganizationID, authz.ErrForbidden},
+	}
+
+	policy := newPolicy(model.TenderStatusPublished, model.BidStatusPublished, bidderID)
+	for _, tt := range tests {
+		check(t, tt.caller.String()+" bid as "+tt.authorType+" "+tt.authorID, policy.CanBidAs(tt.caller.context(), tt.authorType, tt.authorID), tt.want)
+	}
+}
+
+func TestBidAccess(t *testing.T) {
+	type action string
+	const (
+		view   action = "view"
+		edit   action = "edit"
+		decide action = "decide"
+		review action = "review"
+	)
+
+	tests := []struct {
+		caller caller
+		status string
+		action action
+		want   error
+	}{
+		{anonymous, model.BidStatusCreated, view, authz.ErrUnauthenticated},
+		{anonymous, model.BidStatusPublished, edit, authz.ErrUnauthenticated},
+		{anonymous, model.BidStatusPublished, decide, authz.ErrUnauthenticated},
+
+		{bidAuthor, model.BidStatusCreated, view, nil},
+		{bidAuthor, model.BidStatusPublished, view, nil},
+		{bidAuthor, model.BidStatusCanceled, view, nil},
+		{bidAuthor, model.BidStatusCreated, edit, nil},
+		{bidAuthor, model.BidStatusPublished, edit, nil},
+		{bidAuthor, model.BidStatusCreated, decide, authz.ErrForbidden},
+		{bidAuthor, model.BidStatusPublished, decide, authz.ErrForbidden},
+
+		{tenderMember, model.BidStatusCreated, view, authz.ErrNotFound},
+		{tenderMember, model.BidStatusPublished, view, nil},
+		{tenderMember, model.BidStatusCanceled, view, nil},
+		{tenderMember, model.BidStatusCreated, edit, authz.ErrNotFound},
+		{tenderMember, model.BidStatusPublished, edit, authz.ErrForbidden},
+		{tenderMember, model.BidStatusCreated, decide, authz.ErrNotFound},
+		{tenderMember, model.BidStatusPublished, decide, nil},
+		{tenderMember, model.BidStatusCanceled, decide, authz.ErrNotOpen},
+		{tenderMember, model.BidStatusCreated, review, authz.ErrNotFound},
+		{tenderMember, model.BidStatusPublished, review, nil},
+		{tenderMember, model.BidStatusCanceled, review, nil},
+
+		{outsider, model.BidStatusCreated, view, authz.ErrNotFound},
+		{outsider, model.BidStatusPublished, view, authz.ErrForbidden},
+		{outsider, model.BidStatusCanceled, view, authz.ErrForbidden},
+		{outsider, model.BidStatusCreated, edit, authz.ErrNotFound},
+		{outsider, model.BidStatusPublished, edit, authz.ErrForbidden},
+		{outsider, model.BidStatusCreated, decide, authz.ErrNotFound},
+		{outsider, model.BidStatusPublished, decide, authz.ErrForbidden},
+		{outsider, model.BidStatusCanceled, decide, authz.ErrForbidden},
+		{outsider, model.BidStatusPublished, review, authz.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		policy := newPolicy(model.TenderStatusPublished, tt.status, bidderID)
+
+		var got error
+		switch tt.action {
+		case view:
+			got = policy.CanViewBid(tt.caller.context(), bidID)
+		case edit:
+			got = policy.CanEditBid(tt.caller.context(), bidID)
+		case decide:
+			got = policy.CanDecideOnBid(tt.caller.context(), bidID)
+		case review:
+			got = policy.CanReviewBid(tt.caller.context(), bidID)
+		}
+
+		check(t, tt.caller.String()+" "+string(tt.action)+" "+tt.status+" bid", got, tt.want)
+	}
+
+	//A BID SUBMITTED ON BEHALF OF AN ORGANIZATION BELONGS TO EVERY MEMBER OF IT
+	policy := newPolicy(model.TenderStatusPublished, model.BidStatusCreated, bidderOrganizationID)
+	check(t, "bidder member view organization draft", policy.CanViewBid(bidderMember.context(), bidID), nil)
+	check(t, "bidder member edit organization draft", policy.CanEditBid(bidderMember.context(), bidID), nil)
+	check(t, "outsider view missing bid", policy.CanViewBid(outsider.context(), missingBidID), authz.ErrNotFound)
+}
+
+func TestReadReviews(t *testing.T) {
+	tests := []struct {
+		caller    caller
+		bidStatus string
+		author    model.BidAuthor
+		want      error
+	}{
+		{anonymous, model.BidStatusPublished, model.BidAuthor{Type: "User", ID: bidderID}, authz.ErrUnauthenticated},
+		{bidAuthor, model.BidStatusCreated, model.BidAuthor{Type: "User", ID: bidderID}, nil},
+		{bidderMember, model.BidStatusCreated, model.BidAuthor{Type: "Organization", ID: bidderOrganizationID}, nil},
+		{tenderMember, model.BidStatusPublished, model.BidAuthor{Type: "User", ID: bidderID}, nil},
+		{tenderMember, model.BidStatusCreated, model.BidAuthor{Type: "User", ID: bidderID}, authz.ErrForbidden},
+		{outsider, model.BidStatusPublished, model.BidAuthor{Type: "User", ID: bidderID}, authz.ErrForbidden},
+		{bidderMember, model.BidStatusPublished, model.BidAuthor{Type: "User", ID: bidderID}, authz.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		policy := newPolicy(model.TenderStatusPublished, tt.bidStatus, bidderID)
+		check(t, tt.caller.String()+" read reviews of "+tt.author.Type+" "+tt.author.ID+" with a "+tt.bidStatus+" bid",
+			policy.CanReadReviews(tt.caller.context(), tt.author), tt.want)
+	}
+}
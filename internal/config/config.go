@@ -1,16 +1,68 @@
 package config
 
-import "github.com/ilyakaznacheev/cleanenv"
+import (
+	"github.com/ilyakaznacheev/cleanenv"
+	"time"
+)
+
+// DB is read on its own by commands that only need the database, such as migrate.
+type DB struct {
+	PostgresConnStr  string `env:"POSTGRES_CONN"`
+	PostgresUserName string `env:"POSTGRES_USERNAME"`
+	PostgresPassword string `env:"POSTGRES_PASSWORD"`
+	PostgresHost     string `env:"POSTGRES_HOST"`
+	PostgresPort     string `env:"POSTGRES_PORT"`
+	PostgresDatabase string `env:"POSTGRES_DATABASE"`
+}
 
 type Config struct {
 	Address string `env:"SERVER_ADDRESS"`
-	DB      struct {
-		PostgresConnStr  string `env:"POSTGRES_CONN"`
-		PostgresUserName string `env:"POSTGRES_USERNAME"`
-		PostgresPassword string `env:"POSTGRES_PASSWORD"`
-		PostgresHost     string `env:"POSTGRES_HOST"`
-		PostgresPort     string `env:"POSTGRES_PORT"`
-		PostgresDatabase string `env:"POSTGRES_DATABASE"`
+	// Debug additionally checks every response against the OpenAPI specification.
+	Debug bool `env:"DEBUG" env-default:"false"`
+	HTTP  struct {
+		ReadTimeout     time.Duration `env:"HTTP_READ_TIMEOUT" env-default:"5s"`
+		WriteTimeout    time.Duration `env:"HTTP_WRITE_TIMEOUT" env-default:"10s"`
+		IdleTimeout     time.Duration `env:"HTTP_IDLE_TIMEOUT" env-default:"60s"`
+		ShutdownTimeout time.Duration `env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"15s"`
+	}
+	DB   DB
+	Auth struct {
+		JWTSecret string        `env:"AUTH_JWT_SECRET" env-required:"true"`
+		TokenTTL  time.Duration `env:"AUTH_TOKEN_TTL" env-default:"24h"`
+	}
+	// AdminToken enables the admin API, requests authenticate with it in X-Admin-Token.
+	AdminToken string `env:"ADMIN_TOKEN"`
+	// Idempotency keeps responses to keyed requests for TTL. A request that has not finished holds its key for Lease,
+	// which must outlast HTTP.WriteTimeout.
+	Idempotency struct {
+		TTL             time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+		Lease           time.Duration `env:"IDEMPOTENCY_LEASE" env-default:"1m"`
+		CleanupInterval time.Duration `env:"IDEMPOTENCY_CLEANUP_INTERVAL" env-default:"1h"`
+	}
+	// Outbox delivers domain events to the log and, when WebhookURL is set, to a webhook.
+	Outbox struct {
+		PollInterval   time.Duration `env:"OUTBOX_POLL_INTERVAL" env-default:"1s"`
+		BatchSize      int           `env:"OUTBOX_BATCH_SIZE" env-default:"20"`
+		MaxAttempts    int           `env:"OUTBOX_MAX_ATTEMPTS" env-default:"10"`
+		RetryBackoff   time.Duration `env:"OUTBOX_RETRY_BACKOFF" env-default:"10s"`
+		Lease          time.Duration `env:"OUTBOX_LEASE" env-default:"5m"`
+		LogSink        bool          `env:"OUTBOX_LOG_SINK" env-default:"true"`
+		WebhookURL     string        `env:"OUTBOX_WEBHOOK_URL"`
+		WebhookSecret  string        `env:"OUTBOX_WEBHOOK_SECRET"`
+		WebhookTimeout time.Duration `env:"OUTBOX_WEBHOOK_TIMEOUT" env-default:"5s"`
+		WebhookRetries int           `env:"OUTBOX_WEBHOOK_RETRIES" env-default:"3"`
+		WebhookBackoff time.Duration `env:"OUTBOX_WEBHOOK_BACKOFF" env-default:"1s"`
+	}
+	// Webhooks sends events to the endpoints organizations subscribed through the API.
+	Webhooks struct {
+		PollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" env-default:"1s"`
+		BatchSize    int           `env:"WEBHOOK_BATCH_SIZE" env-default:"20"`
+		MaxAttempts  int           `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+		RetryBackoff time.Duration `env:"WEBHOOK_RETRY_BACKOFF" env-default:"30s"`
+		Lease        time.Duration `env:"WEBHOOK_LEASE" env-default:"1m"`
+		Timeout      time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"5s"`
+		// DisableAfter deactivates a subscription after that many failed attempts in a row.
+		DisableAfter int `env:"WEBHOOK_DISABLE_AFTER" env-default:"20"`
 	}
 }
 
@@ -22,3 +74,13 @@ func New() (*Config, error) {
 
 	return cfg, nil
 }
+
+// NewDB reads the database settings only, the settings the server requires are not checked.
+func NewDB() (*DB, error) {
+	cfg := new(DB)
+	if err := cleanenv.ReadEnv(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,63 @@
+package handler_admin_converter
+
+import (
+	handler_admin_model "avito_intership/internal/handlers/admin/model"
+	"avito_intership/internal/model"
+)
+
+func ToEmployeeService(employee handler_admin_model.EmployeeRequest) model.Employee {
+	return model.Employee{
+		Username:  *employee.Username,
+		FirstName: employee.FirstName,
+		LastName:  employee.LastName,
+	}
+}
+
+func EditToEmployeeService(employee handler_admin_model.EmployeeEditRequest) model.EmployeePatch {
+	return model.EmployeePatch{
+		Username:  employee.Username,
+		FirstName: employee.FirstName,
+		LastName:  employee.LastName,
+		Password:  employee.Password,
+	}
+}
+
+func ToEmployeeHandler(employee model.Employee) handler_admin_model.EmployeeResponse {
+	return handler_admin_model.EmployeeResponse{
+		ID:            employee.ID,
+		Username:      employee.Username,
+		FirstName:     employee.FirstName,
+		LastName:      employee.LastName,
+		Active:        employee.DeactivatedAt == nil,
+		DeactivatedAt: employee.DeactivatedAt,
+		CreatedAt:     employee.CreatedAt,
+		UpdatedAt:     employee.UpdatedAt,
+	}
+}
+
+func ArrToEmployeeHandler(employees []model.Employee) []handler_admin_model.EmployeeResponse {
+	employeesResp := make([]handler_admin_model.EmployeeResponse, 0, len(employees))
+	for _, employee := range employees {
+		employeesResp = append(employeesResp, ToEmployeeHandler(employee))
+	}
+	return employeesResp
+}
+
+func ToOrganizationService(organization handler_admin_model.OrganizationRequest) model.Organization {
+	return model.Organization{
+		Name:        *organization.Name,
+		Description: organization.Description,
+		Type:        organization.Type,
+	}
+}
+
+func ToOrganizationHandler(organization model.Organization) handler_admin_model.OrganizationResponse {
+	return handler_admin_model.OrganizationResponse{
+		ID:          organization.ID,
+		Name:        organization.Name,
+		Description: organization.Description,
+		Type:        organization.Type,
+		CreatedAt:   organization.CreatedAt,
+		UpdatedAt:   organization.UpdatedAt,
+	}
+}
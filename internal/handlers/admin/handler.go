@@ -0,0 +1,15 @@
+package handler_admin
+
+import (
+	"net/http"
+)
+
+type Handler interface {
+	CreateEmployee() http.HandlerFunc
+	EditEmployee() http.HandlerFunc
+	DeactivateEmployee() http.HandlerFunc
+	CreateOrganization() http.HandlerFunc
+	Representatives() http.HandlerFunc
+	AddRepresentative() http.HandlerFunc
+	RemoveRepresentative() http.HandlerFunc
+}
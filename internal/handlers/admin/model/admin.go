@@ -0,0 +1,48 @@
+package handler_admin_model
+
+import "time"
+
+type EmployeeRequest struct {
+	Username  *string `json:"username" validate:"required,min=1,max=50"`
+	FirstName *string `json:"firstName" validate:"omitnil,max=50"`
+	LastName  *string `json:"lastName" validate:"omitnil,max=50"`
+	Password  *string `json:"password" validate:"omitnil,min=8,max=72"`
+}
+
+// EmployeeEditRequest carries the fields of an employee to change, every one of them is optional.
+type EmployeeEditRequest struct {
+	Username  *string `json:"username" validate:"omitnil,min=1,max=50"`
+	FirstName *string `json:"firstName" validate:"omitnil,max=50"`
+	LastName  *string `json:"lastName" validate:"omitnil,max=50"`
+	Password  *string `json:"password" validate:"omitnil,min=8,max=72"`
+}
+
+type EmployeeResponse struct {
+	ID            string     `json:"id"`
+	Username      string     `json:"username"`
+	FirstName     *string    `json:"firstName"`
+	LastName      *string    `json:"lastName"`
+	Active        bool       `json:"active"`
+	DeactivatedAt *time.Time `json:"deactivatedAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+type OrganizationRequest struct {
+	Name        *string `json:"name" validate:"required,min=1,max=100"`
+	Description *string `json:"description"`
+	Type        *string `json:"type" validate:"omitnil,oneof=IE LLC JSC"`
+}
+
+type OrganizationResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	Type        *string   `json:"type"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type RepresentativeRequest struct {
+	EmployeeID *string `json:"employeeId" validate:"required,uuid"`
+}
@@ -0,0 +1,254 @@
+package handler_admin_mux_impl
+
+import (
+	"avito_intership/internal/handlers"
+	handler_admin "avito_intership/internal/handlers/admin"
+	handler_admin_converter "avito_intership/internal/handlers/admin/converter"
+	handler_admin_model "avito_intership/internal/handlers/admin/model"
+	"avito_intership/internal/middlewares"
+	service_employee "avito_intership/internal/service/employee"
+	service_organization "avito_intership/internal/service/organization"
+	service_organization_resp "avito_intership/internal/service/organization_responsible"
+	"avito_intership/internal/validator"
+	"avito_intership/pkg/logger"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"log/slog"
+	"net/http"
+)
+
+type handler struct {
+	router *mux.Router
+
+	employeeService         service_employee.Service
+	organizationService     service_organization.Service
+	organizationRespService service_organization_resp.Service
+
+	validator *validator.Validate
+
+	logger *slog.Logger
+}
+
+func (h *handler) CreateEmployee() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		employeeReq := handler_admin_model.EmployeeRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&employeeReq); err != nil {
+			l.Error("Failed to decode body", "error", err.Error())
+			handlers.WriteError(w, handlers.ErrDecodeBody)
+			return
+		}
+
+		if err := h.validator.Validate(employeeReq); err != nil {
+			handlers.WriteValidationError(w, err)
+			return
+		}
+
+		employee, err := h.employeeService.Create(r.Context(), handler_admin_converter.ToEmployeeService(employeeReq), employeeReq.Password)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err = json.NewEncoder(w).Encode(handler_admin_converter.ToEmployeeHandler(employee)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) EditEmployee() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		employeeID := mux.Vars(r)[handler_admin.EmployeeIDUrlPath]
+		if err := uuid.Validate(employeeID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid employee id")
+			return
+		}
+
+		employeeReq := handler_admin_model.EmployeeEditRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&employeeReq); err != nil {
+			l.Error("Failed to decode body", "error", err.Error())
+			handlers.WriteError(w, handlers.ErrDecodeBody)
+			return
+		}
+
+		if err := h.validator.Validate(employeeReq); err != nil {
+			handlers.WriteValidationError(w, err)
+			return
+		}
+
+		employee, err := h.employeeService.Update(r.Context(), employeeID, handler_admin_converter.EditToEmployeeService(employeeReq))
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_admin_converter.ToEmployeeHandler(employee)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) DeactivateEmployee() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		employeeID := mux.Vars(r)[handler_admin.EmployeeIDUrlPath]
+		if err := uuid.Validate(employeeID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid employee id")
+			return
+		}
+
+		if _, err := h.organizationRespService.DeactivateEmployee(r.Context(), employeeID); err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *handler) CreateOrganization() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		organizationReq := handler_admin_model.OrganizationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&organizationReq); err != nil {
+			l.Error("Failed to decode body", "error", err.Error())
+			handlers.WriteError(w, handlers.ErrDecodeBody)
+			return
+		}
+
+		if err := h.validator.Validate(organizationReq); err != nil {
+			handlers.WriteValidationError(w, err)
+			return
+		}
+
+		organization, err := h.organizationService.Create(r.Context(), handler_admin_converter.ToOrganizationService(organizationReq))
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err = json.NewEncoder(w).Encode(handler_admin_converter.ToOrganizationHandler(organization)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Representatives() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		organizationID := mux.Vars(r)[handler_admin.OrganizationIDUrlPath]
+		if err := uuid.Validate(organizationID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid organization id")
+			return
+		}
+
+		representatives, err := h.organizationRespService.Representatives(r.Context(), organizationID)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_admin_converter.ArrToEmployeeHandler(representatives)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) AddRepresentative() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		organizationID := mux.Vars(r)[handler_admin.OrganizationIDUrlPath]
+		if err := uuid.Validate(organizationID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid organization id")
+			return
+		}
+
+		representativeReq := handler_admin_model.RepresentativeRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&representativeReq); err != nil {
+			l.Error("Failed to decode body", "error", err.Error())
+			handlers.WriteError(w, handlers.ErrDecodeBody)
+			return
+		}
+
+		if err := h.validator.Validate(representativeReq); err != nil {
+			handlers.WriteValidationError(w, err)
+			return
+		}
+
+		if err := h.organizationRespService.AddRepresentative(r.Context(), organizationID, *representativeReq.EmployeeID); err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *handler) RemoveRepresentative() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		organizationID := mux.Vars(r)[handler_admin.OrganizationIDUrlPath]
+		if err := uuid.Validate(organizationID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid organization id")
+			return
+		}
+
+		employeeID := mux.Vars(r)[handler_admin.EmployeeIDUrlPath]
+		if err := uuid.Validate(employeeID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid employee id")
+			return
+		}
+
+		if err := h.organizationRespService.RemoveRepresentative(r.Context(), organizationID, employeeID); err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Register mounts the admin API under /api/admin. Without an admin token the API stays disabled.
+func Register(router *mux.Router, employeeService service_employee.Service, organizationService service_organization.Service,
+	organizationRespService service_organization_resp.Service, adminToken string, logger *slog.Logger) error {
+	h := &handler{
+		router:                  router,
+		employeeService:         employeeService,
+		organizationService:     organizationService,
+		organizationRespService: organizationRespService,
+		validator:               validator.New(),
+		logger:                  logger,
+	}
+
+	if adminToken == "" {
+		h.logger.Info("Admin API is disabled, ADMIN_TOKEN is not set")
+		return nil
+	}
+
+	adminRouter := router.PathPrefix("/api/admin").Subrouter()
+
+	adminRouter.Use(middlewares.Log(h.logger), middlewares.Admin(adminToken))
+
+	adminRouter.Path("/employees").Methods(http.MethodPost).Handler(h.CreateEmployee())
+	adminRouter.Path("/employees/{employee_id}").Methods(http.MethodPatch).Handler(h.EditEmployee())
+	adminRouter.Path("/employees/{employee_id}").Methods(http.MethodDelete).Handler(h.DeactivateEmployee())
+	adminRouter.Path("/organizations").Methods(http.MethodPost).Handler(h.CreateOrganization())
+	adminRouter.Path("/organizations/{organization_id}/representatives").Methods(http.MethodGet).Handler(h.Representatives())
+	adminRouter.Path("/organizations/{organization_id}/representatives").Methods(http.MethodPost).Handler(h.AddRepresentative())
+	adminRouter.Path("/organizations/{organization_id}/representatives/{employee_id}").Methods(http.MethodDelete).Handler(h.RemoveRepresentative())
+
+	return nil
+}
@@ -0,0 +1,6 @@
+package handler_admin
+
+var (
+	EmployeeIDUrlPath     = "employee_id"
+	OrganizationIDUrlPath = "organization_id"
+)
@@ -0,0 +1,7 @@
+package handler_auth
+
+import "net/http"
+
+type Handler interface {
+	Login() http.HandlerFunc
+}
@@ -0,0 +1,67 @@
+package handler_auth_mux_impl
+
+import (
+	"avito_intership/internal/handlers"
+	handler_auth_model "avito_intership/internal/handlers/auth/model"
+	"avito_intership/internal/middlewares"
+	service_auth "avito_intership/internal/service/auth"
+	"avito_intership/pkg/logger"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"log/slog"
+	"net/http"
+)
+
+type handler struct {
+	router *mux.Router
+
+	service service_auth.Service
+
+	logger *slog.Logger
+}
+
+func (h *handler) Login() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		loginReq := handler_auth_model.LoginRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&loginReq); err != nil {
+			l.Error("Failed to decode body", "error", err.Error())
+			handlers.WriteError(w, handlers.ErrDecodeBody)
+			return
+		}
+
+		if loginReq.Username == "" || loginReq.Password == "" {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "provide username and password")
+			return
+		}
+
+		token, err := h.service.Login(r.Context(), loginReq.Username, loginReq.Password)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_auth_model.LoginResponse{Token: token}); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func Register(router *mux.Router, service service_auth.Service, logger *slog.Logger) error {
+	h := &handler{
+		router:  router,
+		service: service,
+		logger:  logger,
+	}
+
+	apiRouter := router.PathPrefix("/api").Subrouter()
+
+	apiRouter.Use(middlewares.Log(h.logger))
+
+	apiRouter.Path("/auth/login").Methods(http.MethodPost).Handler(h.Login())
+
+	return nil
+}
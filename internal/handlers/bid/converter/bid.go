@@ -15,6 +15,13 @@ func ToBidService(bid handler_bid_model.BidRequest) model.Bid {
 	}
 }
 
+func EditToBidService(bid handler_bid_model.BidEditRequest) model.Bid {
+	return model.Bid{
+		Name:        bid.Name,
+		Description: bid.Description,
+	}
+}
+
 func ToBidHandler(bid model.Bid) handler_bid_model.BidResponse {
 	return handler_bid_model.BidResponse{
 		ID:         bid.ID,
@@ -24,6 +31,8 @@ func ToBidHandler(bid model.Bid) handler_bid_model.BidResponse {
 		AuthorID:   bid.AuthorID,
 		Version:    bid.Version,
 		CreatedAt:  bid.CreatedAt,
+		ChangedBy:  bid.ChangedBy,
+		ChangeKind: bid.ChangeKind,
 	}
 }
 
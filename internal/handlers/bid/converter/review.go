@@ -0,0 +1,45 @@
+package handler_bid_converter
+
+import (
+	handler_bid_model "avito_intership/internal/handlers/bid/model"
+	"avito_intership/internal/model"
+)
+
+func ToReviewHandler(feedback model.Feedback) handler_bid_model.ReviewResponse {
+	return handler_bid_model.ReviewResponse{
+		ID:          feedback.ID,
+		BidID:       optional(feedback.BidID),
+		TenderID:    optional(feedback.TenderID),
+		AuthorType:  optional(feedback.AuthorType),
+		AuthorID:    optional(feedback.AuthorID),
+		Description: feedback.Description,
+		Rating:      feedback.Rating,
+		CreatedAt:   feedback.CreatedAt,
+		UpdatedAt:   feedback.UpdatedAt,
+	}
+}
+
+func ToReviewsHandler(feedbacks []model.Feedback, stats model.FeedbackStats, nextCursor *string, total *int) handler_bid_model.ReviewsResponse {
+	reviews := make([]handler_bid_model.ReviewResponse, 0, len(feedbacks))
+	for _, feedback := range feedbacks {
+		reviews = append(reviews, ToReviewHandler(feedback))
+	}
+
+	return handler_bid_model.ReviewsResponse{
+		Items:      reviews,
+		NextCursor: nextCursor,
+		Total:      total,
+		Stats: handler_bid_model.ReviewStatsResponse{
+			Count:         stats.Count,
+			AverageRating: stats.AverageRating,
+			Ratings:       stats.Ratings,
+		},
+	}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
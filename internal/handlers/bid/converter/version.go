@@ -0,0 +1,52 @@
+package handler_bid_converter
+
+import (
+	handler_bid_model "avito_intership/internal/handlers/bid/model"
+	"avito_intership/internal/model"
+)
+
+func ToBidVersionHandler(version model.BidVersion) handler_bid_model.BidVersionResponse {
+	return handler_bid_model.BidVersionResponse{
+		BidResponse: ToBidHandler(version.Bid),
+		Description: version.Bid.Description,
+		ChangedAt:   version.ChangedAt,
+	}
+}
+
+func ArrToBidVersionHandler(versions []model.BidVersion) []handler_bid_model.BidVersionResponse {
+	versionsResp := make([]handler_bid_model.BidVersionResponse, 0, len(versions))
+	for _, version := range versions {
+		versionsResp = append(versionsResp, ToBidVersionHandler(version))
+	}
+
+	return versionsResp
+}
+
+// fieldNames are the JSON names of the bid fields a diff compares
+var fieldNames = map[string]string{
+	model.FieldName:        "name",
+	model.FieldDescription: "description",
+	model.FieldStatus:      "status",
+	model.FieldAuthorType:  "author_type",
+	model.FieldAuthorID:    "author_id",
+}
+
+func ToVersionDiffHandler(diff model.VersionDiff) handler_bid_model.VersionDiffResponse {
+	changes := make([]handler_bid_model.FieldChangeResponse, 0, len(diff.Changes))
+	for _, change := range diff.Changes {
+		changes = append(changes, handler_bid_model.FieldChangeResponse{
+			Field: fieldNames[change.Field],
+			From:  change.From,
+			To:    change.To,
+		})
+	}
+
+	return handler_bid_model.VersionDiffResponse{
+		FromVersion: diff.From,
+		ToVersion:   diff.To,
+		ChangedAt:   diff.ChangedAt,
+		ChangedBy:   diff.ChangedBy,
+		ChangeKind:  diff.ChangeKind,
+		Changes:     changes,
+	}
+}
@@ -11,5 +11,9 @@ type Handler interface {
 	Edit() http.HandlerFunc
 	SubmitDecision() http.HandlerFunc
 	Feedback() http.HandlerFunc
+	Reviews() http.HandlerFunc
 	RollbackVersion() http.HandlerFunc
+	Versions() http.HandlerFunc
+	Version() http.HandlerFunc
+	Diff() http.HandlerFunc
 }
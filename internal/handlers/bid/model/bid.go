@@ -15,16 +15,29 @@ type BidResponse struct {
 	AuthorID   *string    `json:"author_id"`
 	Version    *int       `json:"version"`
 	CreatedAt  *time.Time `json:"created_at"`
+	ChangedBy  *string    `json:"changed_by"`
+	ChangeKind *string    `json:"change_kind"`
 }
 
 type BidRequest struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	TenderID    *string `json:"tender_id"`
-	AuthorType  *string `json:"author_type" validate:"author_type"`
-	AuthorID    *string `json:"author_id"`
+	Name        *string `json:"name" validate:"required,min=1,max=100"`
+	Description *string `json:"description" validate:"required,min=1"`
+	TenderID    *string `json:"tender_id" validate:"required,uuid"`
+	AuthorType  *string `json:"author_type" validate:"required,author_type"`
+	AuthorID    *string `json:"author_id" validate:"required,uuid"`
 }
 
+// BidEditRequest carries the content fields of a bid, every one of them is optional.
+// ExpectedVersion makes the edit conditional, the same as the If-Match header.
+type BidEditRequest struct {
+	Name            *string `json:"name" validate:"omitnil,min=1,max=100"`
+	Description     *string `json:"description" validate:"omitnil,min=1"`
+	ExpectedVersion *int    `json:"expected_version" validate:"omitnil,min=1"`
+}
+
+// BidStatusTag validates the status query parameter.
+const BidStatusTag = "required,oneof=Created Published Canceled"
+
 var (
 	PossibleAuthorTypes = []string{"organization", "user"}
 )
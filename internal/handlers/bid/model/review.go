@@ -0,0 +1,33 @@
+package handler_bid_model
+
+import "time"
+
+// ReviewResponse is a review on a bid. Reviews written before bids were recorded have no bid, tender or author.
+type ReviewResponse struct {
+	ID          string     `json:"id"`
+	BidID       *string    `json:"bid_id"`
+	TenderID    *string    `json:"tender_id"`
+	AuthorType  *string    `json:"author_type"`
+	AuthorID    *string    `json:"author_id"`
+	Description string     `json:"description"`
+	Rating      *int       `json:"rating"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+// ReviewStatsResponse sums up every review on the author, not only the returned page.
+type ReviewStatsResponse struct {
+	Count         int         `json:"count"`
+	AverageRating *float64    `json:"average_rating"`
+	Ratings       map[int]int `json:"ratings"`
+}
+
+type ReviewsResponse struct {
+	Items      []ReviewResponse    `json:"items"`
+	NextCursor *string             `json:"nextCursor"`
+	Total      *int                `json:"total,omitempty"`
+	Stats      ReviewStatsResponse `json:"stats"`
+}
+
+// RatingTag validates the rating query parameter.
+const RatingTag = "min=1,max=5"
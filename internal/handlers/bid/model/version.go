@@ -0,0 +1,24 @@
+package handler_bid_model
+
+import "time"
+
+type BidVersionResponse struct {
+	BidResponse
+	Description *string   `json:"description"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+type FieldChangeResponse struct {
+	Field string  `json:"field"`
+	From  *string `json:"from"`
+	To    *string `json:"to"`
+}
+
+type VersionDiffResponse struct {
+	FromVersion int                   `json:"from_version"`
+	ToVersion   int                   `json:"to_version"`
+	ChangedAt   time.Time             `json:"changed_at"`
+	ChangedBy   *string               `json:"changed_by"`
+	ChangeKind  *string               `json:"change_kind"`
+	Changes     []FieldChangeResponse `json:"changes"`
+}
@@ -7,13 +7,10 @@ import (
 	handler_bid_model "avito_intership/internal/handlers/bid/model"
 	handler_tender "avito_intership/internal/handlers/tender"
 	"avito_intership/internal/middlewares"
-	"avito_intership/internal/model"
-	repository_feedback "avito_intership/internal/repository/feedback"
-	repository_tenders "avito_intership/internal/repository/tender"
+	service_auth "avito_intership/internal/service/auth"
 	service_bids "avito_intership/internal/service/bid"
-	service_decision "avito_intership/internal/service/decision"
-	service_employee "avito_intership/internal/service/employee"
-	service_organization_resp "avito_intership/internal/service/organization_responsible"
+	service_feedback "avito_intership/internal/service/feedback"
+	service_idempotency "avito_intership/internal/service/idempotency"
 	"avito_intership/internal/validator"
 	"avito_intership/pkg/logger"
 	"encoding/json"
@@ -72,31 +69,25 @@ func (h *handler) Create() http.HandlerFunc {
 		bidReq := handler_bid_model.BidRequest{}
 		if err := json.NewDecoder(r.Body).Decode(&bidReq); err != nil {
 			l.Error("Failed to decode body", "error", err.Error())
-			http.Error(w, handlers.ErrDecodeBody.Error(), http.StatusBadRequest)
+			handlers.WriteError(w, handlers.ErrDecodeBody)
+			return
+		}
+
+		if err := h.validator.Validate(bidReq); err != nil {
+			handlers.WriteValidationError(w, err)
 			return
 		}
 
 		bid, err := h.service.Create(r.Context(), handler_bid_converter.ToBidService(bidReq))
 		if err != nil {
-			switch {
-			case errors.Is(err, service_bids.ErrInvalidReq):
-				http.Error(w, "invalid request", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_bids.ErrInvalidAuthorID):
-				http.Error(w, "invalid author_id", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_bids.ErrInvalidTenderID):
-				http.Error(w, "invalid tender_id", http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
+		handlers.SetETag(w, bid.Version)
 		w.Header().Add("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		if err = json.NewEncoder(w).Encode(handler_bid_converter.ToBidHandler(bid)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -108,39 +99,36 @@ func (h *handler) BidsByUser() http.HandlerFunc {
 
 		values, err := h.parseURL(r.RequestURI, l)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
 		limit, offset := h.getLimitAndOffsetQueryParams(values.Get(handlers.LimitQueryParam), values.Get(handlers.OffsetQueryParam))
-		username := values.Get(handler_bid.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, errors.New("provide username").Error(), http.StatusUnauthorized)
+		page, envelope, err := handlers.GetPageQueryParams(values, limit, offset)
+		if err != nil {
+			handlers.WriteError(w, err)
 			return
 		}
 
-		bids, err := h.service.BidsByUser(r.Context(), username, limit, offset)
+		bids, pageInfo, err := h.service.BidsByUser(r.Context(), page)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_bids.ErrNoBids):
-				http.Error(w, service_bids.ErrNoBids.Error(), http.StatusNoContent)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			if errors.Is(err, service_bids.ErrNoBids) {
+				w.WriteHeader(http.StatusNoContent)
 				return
 			}
+			handlers.WriteError(w, err)
+			return
+		}
+
+		var response interface{} = handler_bid_converter.ArrToBidHandler(bids)
+		if envelope {
+			response = handlers.NewPageResponse(handler_bid_converter.ArrToBidHandler(bids), pageInfo)
 		}
 
 		w.Header().Add("Content-Type", "application/json")
 		encoder := json.NewEncoder(w)
-		if err = encoder.Encode(handler_bid_converter.ArrToBidHandler(bids)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err = encoder.Encode(response); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -152,52 +140,43 @@ func (h *handler) BidsByTenderID() http.HandlerFunc {
 
 		values, err := h.parseURL(r.RequestURI, l)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
 		limit, offset := h.getLimitAndOffsetQueryParams(values.Get(handlers.LimitQueryParam), values.Get(handlers.OffsetQueryParam))
 
 		tenderID := mux.Vars(r)[handler_bid.TenderIDUrlPath]
 		if err = uuid.Validate(tenderID); err != nil {
-			http.Error(w, "invalid tender id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid tender id")
 			return
 		}
 
-		username := values.Get(handler_bid.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, "provide username", http.StatusBadRequest)
+		page, envelope, err := handlers.GetPageQueryParams(values, limit, offset)
+		if err != nil {
+			handlers.WriteError(w, err)
 			return
 		}
 
-		bids, err := h.service.BidsByTenderID(r.Context(), tenderID, username, limit, offset)
+		bids, pageInfo, err := h.service.BidsByTenderID(r.Context(), tenderID, page)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_employee.ErrNonExistingEmployee):
-				http.Error(w, service_employee.ErrNonExistingEmployee.Error(), http.StatusUnauthorized)
-				return
-			case errors.Is(err, service_bids.ErrForbidden):
-				http.Error(w, "permission denied", http.StatusForbidden)
-				return
-			case errors.Is(err, service_bids.ErrNoBids):
-				http.Error(w, service_bids.ErrNoBids.Error(), http.StatusNoContent)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			if errors.Is(err, service_bids.ErrNoBids) {
+				w.WriteHeader(http.StatusNoContent)
 				return
 			}
+			handlers.WriteError(w, err)
+			return
+		}
+
+		var response interface{} = handler_bid_converter.ArrToBidHandler(bids)
+		if envelope {
+			response = handlers.NewPageResponse(handler_bid_converter.ArrToBidHandler(bids), pageInfo)
 		}
 
 		w.Header().Add("Content-Type", "application/json")
 		encoder := json.NewEncoder(w)
-		if err = encoder.Encode(handler_bid_converter.ArrToBidHandler(bids)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err = encoder.Encode(response); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -205,52 +184,21 @@ func (h *handler) BidsByTenderID() http.HandlerFunc {
 
 func (h *handler) GetStatus() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		l := logger.EndToEndLogging(r.Context(), h.logger)
-
 		bidID := mux.Vars(r)[handler_bid.BidIDUrlPath]
 		if err := uuid.Validate(bidID); err != nil {
-			http.Error(w, "invalid bid id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid bid id")
 			return
 		}
 
-		values, err := h.parseURL(r.RequestURI, l)
+		status, err := h.service.GetStatus(r.Context(), bidID)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
-		}
-
-		username := values.Get(handler_bid.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, "provide username", http.StatusBadRequest)
+			handlers.WriteError(w, err)
 			return
 		}
 
-		status, err := h.service.GetStatus(r.Context(), bidID, username)
-		if err != nil {
-			switch {
-			case errors.Is(err, service_employee.ErrNonExistingEmployee):
-				http.Error(w, service_employee.ErrNonExistingEmployee.Error(), http.StatusUnauthorized)
-				return
-			case errors.Is(err, service_bids.ErrNoBids):
-				http.Error(w, service_bids.ErrNoBids.Error(), http.StatusNoContent)
-				return
-			case errors.Is(err, service_bids.ErrForbidden):
-				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
-		}
-
+		w.Header().Add("Content-Type", "application/json")
 		if err = json.NewEncoder(w).Encode(status); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -262,57 +210,31 @@ func (h *handler) ChangeStatus() http.HandlerFunc {
 
 		bidID := mux.Vars(r)[handler_bid.BidIDUrlPath]
 		if err := uuid.Validate(bidID); err != nil {
-			http.Error(w, "invalid bid id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid bid id")
 			return
 		}
 
 		values, err := h.parseURL(r.RequestURI, l)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
-		}
-
-		status := values.Get(handler_bid.StatusQueryParam)
-		if status == "" {
-			http.Error(w, "provide status", http.StatusBadRequest)
+			handlers.WriteError(w, err)
 			return
 		}
 
-		username := values.Get(handler_bid.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, "provide username", http.StatusBadRequest)
+		status := values.Get(handler_bid.StatusQueryParam)
+		if err = h.validator.Var(handler_bid.StatusQueryParam, status, handler_bid_model.BidStatusTag); err != nil {
+			handlers.WriteValidationError(w, err)
 			return
 		}
 
-		bid, err := h.service.ChangeStatus(r.Context(), bidID, username, status)
+		bid, err := h.service.ChangeStatus(r.Context(), bidID, status)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_bids.ErrInvalidBidStatus):
-				http.Error(w, "invalid bid status", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_employee.ErrNonExistingEmployee):
-				http.Error(w, service_employee.ErrNonExistingEmployee.Error(), http.StatusUnauthorized)
-				return
-			case errors.Is(err, service_bids.ErrNoBids):
-				http.Error(w, service_bids.ErrNoBids.Error(), http.StatusBadRequest)
-				return
-			case errors.Is(err, service_bids.ErrForbidden):
-				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
+		handlers.SetETag(w, bid.Version)
 		if err = json.NewEncoder(w).Encode(handler_bid_converter.ToBidHandler(bid)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -322,58 +244,43 @@ func (h *handler) Edit() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		l := logger.EndToEndLogging(r.Context(), h.logger)
 
-		bid := model.Bid{}
-
-		if err := json.NewDecoder(r.Body).Decode(&bid); err != nil {
+		bidReq := handler_bid_model.BidEditRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&bidReq); err != nil {
 			l.Error("Failed to decode body", "error", err.Error())
-			http.Error(w, handlers.ErrDecodeBody.Error(), http.StatusBadRequest)
+			handlers.WriteError(w, handlers.ErrDecodeBody)
+			return
+		}
+
+		if err := h.validator.Validate(bidReq); err != nil {
+			handlers.WriteValidationError(w, err)
 			return
 		}
 
 		bidID := mux.Vars(r)[handler_bid.BidIDUrlPath]
 		if err := uuid.Validate(bidID); err != nil {
-			http.Error(w, "invalid bid id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid bid id")
 			return
 		}
 
-		values, err := h.parseURL(r.RequestURI, l)
+		expectedVersion, err := handlers.ExpectedVersion(r, bidReq.ExpectedVersion)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
-		}
-
-		username := values.Get(handler_bid.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, "provide username", http.StatusBadRequest)
+			handlers.WriteError(w, err)
 			return
 		}
 
-		updatedBid, err := h.service.Edit(r.Context(), bidID, username, bid)
+		updatedBid, err := h.service.Edit(r.Context(), bidID, handler_bid_converter.EditToBidService(bidReq), expectedVersion)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_bids.ErrNoSuggestionToUpdate):
-				http.Error(w, service_bids.ErrNoSuggestionToUpdate.Error(), http.StatusBadRequest)
-				return
-			case errors.Is(err, service_bids.ErrNoBids):
-				http.Error(w, service_bids.ErrNoBids.Error(), http.StatusBadRequest)
-				return
-			case errors.Is(err, service_bids.ErrForbidden):
-				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			if errors.Is(err, service_bids.ErrVersionConflict) {
+				handlers.WriteConflict(w, err, handler_bid_converter.ToBidHandler(updatedBid), updatedBid.Version)
 				return
 			}
+			handlers.WriteError(w, err)
+			return
 		}
 
+		handlers.SetETag(w, updatedBid.Version)
 		if err = json.NewEncoder(w).Encode(handler_bid_converter.ToBidHandler(updatedBid)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -385,75 +292,34 @@ func (h *handler) SubmitDecision() http.HandlerFunc {
 
 		bidID := mux.Vars(r)[handler_bid.BidIDUrlPath]
 		if err := uuid.Validate(bidID); err != nil {
-			http.Error(w, "invalid bid id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid bid id")
 			return
 		}
 
 		values, err := h.parseURL(r.RequestURI, l)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
 		decision := values.Get(handler_bid.DecisionQueryParam)
 		if decision == "" {
-			http.Error(w, "provide decision", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "provide decision")
 			return
 		}
 
-		username := values.Get(handler_bid.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, errors.New("provide username").Error(), http.StatusUnauthorized)
-			return
-		}
-
-		bid, isWinner, err := h.service.SubmitDecision(r.Context(), bidID, decision, username)
+		//A WINNING DECISION CLOSES THE TENDER, THE CLIENT LEARNS IT FROM THE TENDER STATUS
+		bid, _, err := h.service.SubmitDecision(r.Context(), bidID, decision)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_decision.ErrUserAlreadyVoted):
-				http.Error(w, "can not vote twice", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_bids.ErrTenderClosed):
-				http.Error(w, "tender has been closed", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_bids.ErrBidBeenRejected):
-				http.Error(w, "bid been rejected", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_employee.ErrNonExistingEmployee):
-				http.Error(w, service_employee.ErrNonExistingEmployee.Error(), http.StatusUnauthorized)
-				return
-			case errors.Is(err, service_organization_resp.ErrUserHasNoOrganization):
-				http.Error(w, service_organization_resp.ErrUserHasNoOrganization.Error(), http.StatusUnauthorized)
-				return
-			case errors.Is(err, service_bids.ErrForbidden):
-				http.Error(w, service_bids.ErrForbidden.Error(), http.StatusForbidden)
-				return
-			case errors.Is(err, service_decision.ErrInvalidReference):
-				http.Error(w, service_decision.ErrInvalidReference.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
+		handlers.SetETag(w, bid.Version)
 		w.Header().Add("Content-Type", "application/json")
-		if isWinner {
-			if _, err = w.Write([]byte("success: tender closed, contractor found\n")); err != nil {
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
-		}
-
 		en := json.NewEncoder(w)
 		if err = en.Encode(handler_bid_converter.ToBidHandler(bid)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -465,53 +331,48 @@ func (h *handler) Feedback() http.HandlerFunc {
 
 		bidID := mux.Vars(r)[handler_bid.BidIDUrlPath]
 		if err := uuid.Validate(bidID); err != nil {
-			http.Error(w, "invalid bid id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid bid id")
 			return
 		}
 
 		values, err := h.parseURL(r.RequestURI, l)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
 		feedback := values.Get(handler_bid.FeedbackQueryParam)
 		if feedback == "" {
-			http.Error(w, "provide feedback", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "provide feedback")
 			return
 		}
 
-		username := values.Get(handler_bid.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, errors.New("provide username").Error(), http.StatusUnauthorized)
-			return
-		}
-
-		bid, err := h.service.Feedback(r.Context(), bidID, username, feedback)
-		if err != nil {
-			switch {
-			case errors.Is(err, service_bids.ErrForbidden):
-				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
-				return
-			case errors.Is(err, service_bids.ErrNoBids) || errors.Is(err, service_organization_resp.ErrUserHasNoOrganization):
-				http.Error(w, "invalid bid_id", http.StatusBadRequest)
+		var rating *int
+		if ratingStr := values.Get(handler_bid.RatingQueryParam); ratingStr != "" {
+			value, err := strconv.Atoi(ratingStr)
+			if err != nil {
+				handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid rating")
 				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+
+			if err = h.validator.Var(handler_bid.RatingQueryParam, value, handler_bid_model.RatingTag); err != nil {
+				handlers.WriteValidationError(w, err)
 				return
 			}
+			rating = &value
 		}
 
+		bid, err := h.service.Feedback(r.Context(), bidID, feedback, rating)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		handlers.SetETag(w, bid.Version)
 		w.Header().Add("Content-Type", "application/json")
 		encoder := json.NewEncoder(w)
 		if err = encoder.Encode(handler_bid_converter.ToBidHandler(bid)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -519,156 +380,185 @@ func (h *handler) Feedback() http.HandlerFunc {
 
 func (h *handler) RollbackVersion() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		l := logger.EndToEndLogging(r.Context(), h.logger)
-
 		bidID := mux.Vars(r)[handler_bid.BidIDUrlPath]
 		if err := uuid.Validate(bidID); err != nil {
-			http.Error(w, "invalid bid id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid bid id")
 			return
 		}
 
 		versionStr := mux.Vars(r)[handler_bid.VersionPath]
 		if versionStr == "" {
-			http.Error(w, "invalid version", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid version")
 			return
 		}
 
 		version, err := strconv.Atoi(versionStr)
 		if err != nil {
-			http.Error(w, "invalid version", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid version")
+			return
+		}
+
+		bid, err := h.service.RollbackVersion(r.Context(), bidID, version)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		handlers.SetETag(w, bid.Version)
+		w.Header().Add("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		if err = encoder.Encode(handler_bid_converter.ToBidHandler(bid)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Reviews() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		tenderID := mux.Vars(r)[handler_tender.TenderIDUrlPath]
+		if err := uuid.Validate(tenderID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid tender id")
 			return
 		}
 
 		values, err := h.parseURL(r.RequestURI, l)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
-		username := values.Get(handler_bid.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, errors.New("provide username").Error(), http.StatusUnauthorized)
+		authorUsername := values.Get(handler_bid.AuthorUsernameQueryParam)
+		if authorUsername == "" {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid author username")
 			return
 		}
 
-		bid, err := h.service.RollbackVersion(r.Context(), bidID, username, version)
+		limit, offset := h.getLimitAndOffsetQueryParams(values.Get(handlers.LimitQueryParam), values.Get(handlers.OffsetQueryParam))
+
+		page, _, err := handlers.GetPageQueryParams(values, limit, offset)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_bids.ErrNoBids):
-				http.Error(w, "invalid version", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_employee.ErrNonExistingEmployee):
-				http.Error(w, "invalid username", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_bids.ErrForbidden):
-				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
-				return
-			case errors.Is(err, service_organization_resp.ErrUserHasNoOrganization):
-				http.Error(w, "user even has no organization", http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, err)
+			return
+		}
+
+		reviews, stats, pageInfo, err := h.service.GetReviews(r.Context(), tenderID, authorUsername, page)
+		if err != nil {
+			if errors.Is(err, service_feedback.ErrNoReviews) {
+				w.WriteHeader(http.StatusNoContent)
 				return
 			}
+			handlers.WriteError(w, err)
+			return
 		}
 
+		//THE STATS NEED AN OBJECT, SO REVIEWS ARE ALWAYS SENT AS A PAGE
+		response := handler_bid_converter.ToReviewsHandler(reviews, stats, handlers.EncodeCursor(pageInfo.NextCursor), pageInfo.Total)
+
 		w.Header().Add("Content-Type", "application/json")
 		encoder := json.NewEncoder(w)
-		if err = encoder.Encode(handler_bid_converter.ToBidHandler(bid)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err = encoder.Encode(response); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
 }
 
-func (h *handler) Reviews() http.HandlerFunc {
+func (h *handler) Versions() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		l := logger.EndToEndLogging(r.Context(), h.logger)
+		bidID := mux.Vars(r)[handler_bid.BidIDUrlPath]
+		if err := uuid.Validate(bidID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid bid id")
+			return
+		}
 
-		tenderID := mux.Vars(r)[handler_tender.TenderIDUrlPath]
-		if err := uuid.Validate(tenderID); err != nil {
-			http.Error(w, "invalid tender id", http.StatusBadRequest)
+		versions, err := h.service.Versions(r.Context(), bidID)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_bid_converter.ArrToBidVersionHandler(versions)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Version() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bidID := mux.Vars(r)[handler_bid.BidIDUrlPath]
+		if err := uuid.Validate(bidID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid bid id")
 			return
 		}
 
-		value, err := h.parseURL(r.RequestURI, l)
+		version, err := strconv.Atoi(mux.Vars(r)[handler_bid.VersionPath])
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid version")
+			return
 		}
 
-		authorUsername := value.Get(handler_bid.AuthorUsernameQueryParam)
-		if authorUsername == "" {
-			http.Error(w, "invalid author username", http.StatusBadRequest)
+		bidVersion, err := h.service.Version(r.Context(), bidID, version)
+		if err != nil {
+			handlers.WriteError(w, err)
 			return
 		}
 
-		requesterUsername := value.Get(handler_bid.RequesterUsernameQueryParam)
-		if requesterUsername == "" {
-			http.Error(w, "invalid requester username", http.StatusBadRequest)
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_bid_converter.ToBidVersionHandler(bidVersion)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Diff() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		bidID := mux.Vars(r)[handler_bid.BidIDUrlPath]
+		if err := uuid.Validate(bidID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid bid id")
 			return
 		}
 
 		values, err := h.parseURL(r.RequestURI, l)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
-		limit, offset := h.getLimitAndOffsetQueryParams(values.Get(handlers.LimitQueryParam), values.Get(handlers.OffsetQueryParam))
+		from, err := strconv.Atoi(values.Get(handlers.FromVersionQueryParam))
+		if err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid from version")
+			return
+		}
 
-		reviews, err := h.service.GetReviews(r.Context(), tenderID, authorUsername, requesterUsername, limit, offset)
+		to, err := strconv.Atoi(values.Get(handlers.ToVersionQueryParam))
 		if err != nil {
-			switch {
-			case errors.Is(err, service_employee.ErrNonExistingEmployee):
-				http.Error(w, "invalid username", http.StatusUnauthorized)
-				return
-			case errors.Is(err, service_organization_resp.ErrUserHasNoOrganization):
-				http.Error(w, "user even has no organization", http.StatusBadRequest)
-				return
-			case errors.Is(err, repository_tenders.ErrNoTenders):
-				http.Error(w, "invalid tender id", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_bids.ErrForbidden):
-				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
-				return
-			case errors.Is(err, repository_feedback.ErrNoReviews):
-				w.WriteHeader(http.StatusNoContent)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid to version")
+			return
+		}
+
+		diff, err := h.service.Diff(r.Context(), bidID, from, to)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
 		}
 
 		w.Header().Add("Content-Type", "application/json")
-		encoder := json.NewEncoder(w)
-		if err = encoder.Encode(reviews); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err = json.NewEncoder(w).Encode(handler_bid_converter.ToVersionDiffHandler(diff)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
 }
 
-func Register(router *mux.Router, service service_bids.Service, logger *slog.Logger) error {
+func Register(router *mux.Router, service service_bids.Service, authService service_auth.Service, idempotencyService service_idempotency.Service, logger *slog.Logger) error {
 	h := &handler{
 		router:    router,
 		service:   service,
@@ -683,18 +573,23 @@ func Register(router *mux.Router, service service_bids.Service, logger *slog.Log
 
 	apiRouter := router.PathPrefix("/api").Subrouter()
 
-	apiRouter.Use(middlewares.Log(h.logger))
+	apiRouter.Use(middlewares.Log(h.logger), middlewares.Auth(authService, h.logger))
+
+	idempotent := middlewares.Idempotency(idempotencyService, h.logger)
 
-	apiRouter.Path("/bids/new").Methods(http.MethodPost).Handler(h.Create())
+	apiRouter.Path("/bids/new").Methods(http.MethodPost).Handler(idempotent(h.Create()))
 	apiRouter.Path("/bids/my").Methods(http.MethodGet).Handler(h.BidsByUser())
 	apiRouter.Path("/bids/{tender_id}/list").Methods(http.MethodGet).Handler(h.BidsByTenderID())
 	apiRouter.Path("/bids/{bid_id}/status").Methods(http.MethodGet).Handler(h.GetStatus())
 	apiRouter.Path("/bids/{bid_id}/status").Methods(http.MethodPut).Handler(h.ChangeStatus())
 	apiRouter.Path("/bids/{bid_id}/edit").Methods(http.MethodPatch).Handler(h.Edit())
-	apiRouter.Path("/bids/{bid_id}/submit_decision").Methods(http.MethodPut).Handler(h.SubmitDecision())
+	apiRouter.Path("/bids/{bid_id}/submit_decision").Methods(http.MethodPut).Handler(idempotent(h.SubmitDecision()))
 	apiRouter.Path("/bids/{bid_id}/feedback").Methods(http.MethodPut).Handler(h.Feedback())
 	apiRouter.Path("/bids/{bid_id}/rollback/{version}").Methods(http.MethodPut).Handler(h.RollbackVersion())
 	apiRouter.Path("/bids/{tender_id}/reviews").Methods(http.MethodGet).Handler(h.Reviews())
+	apiRouter.Path("/bids/{bid_id}/versions").Methods(http.MethodGet).Handler(h.Versions())
+	apiRouter.Path("/bids/{bid_id}/versions/{version}").Methods(http.MethodGet).Handler(h.Version())
+	apiRouter.Path("/bids/{bid_id}/diff").Methods(http.MethodGet).Handler(h.Diff())
 
 	return nil
 }
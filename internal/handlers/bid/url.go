@@ -1,12 +1,11 @@
 package handler_bid
 
 var (
-	UsernameQueryParam          = "username"
-	StatusQueryParam            = "status"
-	DecisionQueryParam          = "decision"
-	FeedbackQueryParam          = "bidFeedback"
-	AuthorUsernameQueryParam    = "authorUsername"
-	RequesterUsernameQueryParam = "requesterUsername"
+	StatusQueryParam         = "status"
+	DecisionQueryParam       = "decision"
+	FeedbackQueryParam       = "bidFeedback"
+	RatingQueryParam         = "rating"
+	AuthorUsernameQueryParam = "authorUsername"
 )
 
 var (
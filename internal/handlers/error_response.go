@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	service_auth "avito_intership/internal/service/auth"
+	service_bids "avito_intership/internal/service/bid"
+	service_decision "avito_intership/internal/service/decision"
+	service_employee "avito_intership/internal/service/employee"
+	service_idempotency "avito_intership/internal/service/idempotency"
+	service_organization "avito_intership/internal/service/organization"
+	service_organization_resp "avito_intership/internal/service/organization_responsible"
+	service_supplier "avito_intership/internal/service/supplier"
+	service_tenders "avito_intership/internal/service/tender"
+	service_webhook "avito_intership/internal/service/webhook"
+	"avito_intership/internal/validator"
+	"encoding/json"
+	"errors"
+	"github.com/hashicorp/go-multierror"
+	"net/http"
+)
+
+// Code is a stable machine-readable error code. Clients should branch on it, the reason text may change.
+type Code string
+
+const (
+	CodeInvalidBody               Code = "INVALID_BODY"
+	CodeValidationFailed          Code = "VALIDATION_FAILED"
+	CodeInvalidParameter          Code = "INVALID_PARAMETER"
+	CodeInvalidCursor             Code = "INVALID_CURSOR"
+	CodeInvalidStatus             Code = "INVALID_STATUS"
+	CodeInvalidServiceType        Code = "INVALID_SERVICE_TYPE"
+	CodeInvalidSort               Code = "INVALID_SORT"
+	CodeInvalidReference          Code = "INVALID_REFERENCE"
+	CodeNothingToUpdate           Code = "NOTHING_TO_UPDATE"
+	CodeOrganizationRequired      Code = "ORGANIZATION_REQUIRED"
+	CodeInvalidDecisionPolicy     Code = "INVALID_DECISION_POLICY"
+	CodeApproverNotRepresentative Code = "APPROVER_NOT_REPRESENTATIVE"
+	CodeTooManyApprovalsRequired  Code = "TOO_MANY_APPROVALS_REQUIRED"
+
+	CodeUnauthenticated    Code = "UNAUTHENTICATED"
+	CodeInvalidToken       Code = "INVALID_TOKEN"
+	CodeInvalidCredentials Code = "INVALID_CREDENTIALS"
+	CodeForbidden          Code = "FORBIDDEN"
+	CodeNoOrganization     Code = "NO_ORGANIZATION"
+
+	CodeTenderNotFound   Code = "TENDER_NOT_FOUND"
+	CodeBidNotFound      Code = "BID_NOT_FOUND"
+	CodeSupplierNotFound Code = "SUPPLIER_NOT_FOUND"
+	CodeWebhookNotFound  Code = "WEBHOOK_NOT_FOUND"
+	CodeDeliveryNotFound Code = "DELIVERY_NOT_FOUND"
+
+	CodeEmployeeNotFound       Code = "EMPLOYEE_NOT_FOUND"
+	CodeOrganizationNotFound   Code = "ORGANIZATION_NOT_FOUND"
+	CodeRepresentativeNotFound Code = "REPRESENTATIVE_NOT_FOUND"
+
+	CodeVersionConflict         Code = "VERSION_CONFLICT"
+	CodeInvalidStatusTransition Code = "INVALID_STATUS_TRANSITION"
+	CodeTenderClosed            Code = "TENDER_CLOSED"
+	CodeTenderNotPublished      Code = "TENDER_NOT_PUBLISHED"
+	CodeAlreadyVoted            Code = "ALREADY_VOTED"
+	CodeBidRejected             Code = "BID_REJECTED"
+	CodeIdempotencyKeyReused    Code = "IDEMPOTENCY_KEY_REUSED"
+	CodeRequestInProgress       Code = "REQUEST_IN_PROGRESS"
+	CodeUsernameTaken           Code = "USERNAME_TAKEN"
+	CodeAlreadyRepresentative   Code = "ALREADY_REPRESENTATIVE"
+	CodeEmployeeDeactivated     Code = "EMPLOYEE_DEACTIVATED"
+	CodeActiveApprover          Code = "ACTIVE_APPROVER"
+
+	CodeInternal Code = "INTERNAL"
+)
+
+// ErrorResponse is the body of every non-2xx response. Fields is only set for VALIDATION_FAILED,
+// Current only for VERSION_CONFLICT.
+type ErrorResponse struct {
+	Reason  string               `json:"reason"`
+	Code    Code                 `json:"code"`
+	Fields  []FieldErrorResponse `json:"fields,omitempty"`
+	Current any                  `json:"current,omitempty"`
+}
+
+type FieldErrorResponse struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+type errorTranslation struct {
+	err    error
+	status int
+	code   Code
+}
+
+// errorTranslations maps sentinel errors to the status and code sent to the client.
+// The reason is the sentinel text, so wrapped details never leak into the response.
+var errorTranslations = []errorTranslation{
+	{err: ErrDecodeBody, status: http.StatusBadRequest, code: CodeInvalidBody},
+	{err: ErrInvalidURLParams, status: http.StatusBadRequest, code: CodeInvalidParameter},
+	{err: ErrInvalidCursor, status: http.StatusBadRequest, code: CodeInvalidCursor},
+	{err: ErrInvalidIfMatch, status: http.StatusBadRequest, code: CodeInvalidParameter},
+	{err: ErrVersionMismatch, status: http.StatusBadRequest, code: CodeInvalidParameter},
+
+	{err: service_auth.ErrInvalidCredentials, status: http.StatusUnauthorized, code: CodeInvalidCredentials},
+	{err: service_auth.ErrInvalidToken, status: http.StatusUnauthorized, code: CodeInvalidToken},
+	{err: service_organization_resp.ErrUserHasNoOrganization, status: http.StatusForbidden, code: CodeNoOrganization},
+
+	{err: service_tenders.ErrUnauthorized, status: http.StatusUnauthorized, code: CodeUnauthenticated},
+	{err: service_tenders.ErrForbidden, status: http.StatusForbidden, code: CodeForbidden},
+	{err: service_tenders.ErrNoTenders, status: http.StatusNotFound, code: CodeTenderNotFound},
+	{err: service_tenders.ErrNoSuggestionToUpdate, status: http.StatusBadRequest, code: CodeNothingToUpdate},
+	{err: service_tenders.ErrInvalidStatus, status: http.StatusBadRequest, code: CodeInvalidStatus},
+	{err: service_tenders.ErrInvalidServiceType, status: http.StatusBadRequest, code: CodeInvalidServiceType},
+	{err: service_tenders.ErrOrganizationRequired, status: http.StatusBadRequest, code: CodeOrganizationRequired},
+	{err: service_tenders.ErrInvalidDecisionPolicy, status: http.StatusBadRequest, code: CodeInvalidDecisionPolicy},
+	{err: service_tenders.ErrApproverNotRepresentative, status: http.StatusBadRequest, code: CodeApproverNotRepresentative},
+	{err: service_tenders.ErrTooManyApprovalsRequired, status: http.StatusBadRequest, code: CodeTooManyApprovalsRequired},
+	{err: service_tenders.ErrInvalidSort, status: http.StatusBadRequest, code: CodeInvalidSort},
+	{err: service_tenders.ErrInvalidCursor, status: http.StatusBadRequest, code: CodeInvalidCursor},
+	{err: service_tenders.ErrVersionConflict, status: http.StatusConflict, code: CodeVersionConflict},
+	{err: service_tenders.ErrInvalidStatusTransition, status: http.StatusConflict, code: CodeInvalidStatusTransition},
+	{err: service_tenders.ErrTenderClosed, status: http.StatusConflict, code: CodeTenderClosed},
+
+	{err: service_bids.ErrUnauthorized, status: http.StatusUnauthorized, code: CodeUnauthenticated},
+	{err: service_bids.ErrForbidden, status: http.StatusForbidden, code: CodeForbidden},
+	{err: service_bids.ErrNoBids, status: http.StatusNotFound, code: CodeBidNotFound},
+	{err: service_bids.ErrInvalidReq, status: http.StatusBadRequest, code: CodeInvalidBody},
+	{err: service_bids.ErrInvalidAuthorID, status: http.StatusBadRequest, code: CodeInvalidReference},
+	{err: service_bids.ErrInvalidTenderID, status: http.StatusBadRequest, code: CodeInvalidReference},
+	{err: service_bids.ErrInvalidBidStatus, status: http.StatusBadRequest, code: CodeInvalidStatus},
+	{err: service_bids.ErrNoSuggestionToUpdate, status: http.StatusBadRequest, code: CodeNothingToUpdate},
+	{err: service_bids.ErrVersionConflict, status: http.StatusConflict, code: CodeVersionConflict},
+	{err: service_bids.ErrInvalidStatusTransition, status: http.StatusConflict, code: CodeInvalidStatusTransition},
+	{err: service_bids.ErrTenderClosed, status: http.StatusConflict, code: CodeTenderClosed},
+	{err: service_bids.ErrTenderNotPublished, status: http.StatusConflict, code: CodeTenderNotPublished},
+	{err: service_bids.ErrBidBeenRejected, status: http.StatusConflict, code: CodeBidRejected},
+
+	{err: service_decision.ErrUserAlreadyVoted, status: http.StatusConflict, code: CodeAlreadyVoted},
+	{err: service_decision.ErrInvalidReference, status: http.StatusBadRequest, code: CodeInvalidReference},
+
+	{err: service_supplier.ErrUnauthorized, status: http.StatusUnauthorized, code: CodeUnauthenticated},
+	{err: service_supplier.ErrNoSupplier, status: http.StatusNotFound, code: CodeSupplierNotFound},
+
+	{err: service_webhook.ErrUnauthorized, status: http.StatusUnauthorized, code: CodeUnauthenticated},
+	{err: service_webhook.ErrNoOrganization, status: http.StatusForbidden, code: CodeNoOrganization},
+	{err: service_webhook.ErrForbidden, status: http.StatusForbidden, code: CodeForbidden},
+	{err: service_webhook.ErrOrganizationRequired, status: http.StatusBadRequest, code: CodeOrganizationRequired},
+	{err: service_webhook.ErrNoSubscription, status: http.StatusNotFound, code: CodeWebhookNotFound},
+	{err: service_webhook.ErrNoDelivery, status: http.StatusNotFound, code: CodeDeliveryNotFound},
+	{err: service_webhook.ErrNoSuggestionToUpdate, status: http.StatusBadRequest, code: CodeNothingToUpdate},
+
+	{err: service_employee.ErrNonExistingEmployee, status: http.StatusNotFound, code: CodeEmployeeNotFound},
+	{err: service_employee.ErrUsernameTaken, status: http.StatusConflict, code: CodeUsernameTaken},
+	{err: service_employee.ErrNoSuggestionToUpdate, status: http.StatusBadRequest, code: CodeNothingToUpdate},
+
+	{err: service_organization.ErrNoOrganization, status: http.StatusNotFound, code: CodeOrganizationNotFound},
+	{err: service_organization_resp.ErrNotRepresentative, status: http.StatusNotFound, code: CodeRepresentativeNotFound},
+	{err: service_organization_resp.ErrAlreadyRepresentative, status: http.StatusConflict, code: CodeAlreadyRepresentative},
+	{err: service_organization_resp.ErrEmployeeDeactivated, status: http.StatusConflict, code: CodeEmployeeDeactivated},
+	{err: service_organization_resp.ErrApprover, status: http.StatusConflict, code: CodeActiveApprover},
+
+	{err: service_idempotency.ErrKeyReused, status: http.StatusUnprocessableEntity, code: CodeIdempotencyKeyReused},
+	{err: service_idempotency.ErrRequestInProgress, status: http.StatusConflict, code: CodeRequestInProgress},
+}
+
+// WriteError translates err through the error table, anything unknown is answered with 500.
+func WriteError(w http.ResponseWriter, err error) {
+	for _, t := range errorTranslations {
+		if errors.Is(err, t.err) {
+			WriteErrorReason(w, t.status, t.code, t.err.Error())
+			return
+		}
+	}
+
+	WriteErrorReason(w, http.StatusInternalServerError, CodeInternal, ErrInternal.Error())
+}
+
+// WriteValidationError answers 400 with one entry per field rejected by the validator.
+func WriteValidationError(w http.ResponseWriter, err error) {
+	response := ErrorResponse{
+		Reason: "request validation failed",
+		Code:   CodeValidationFailed,
+	}
+
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		for _, e := range merr.Errors {
+			var fieldErr validator.FieldError
+			if errors.As(e, &fieldErr) {
+				response.Fields = append(response.Fields, FieldErrorResponse{Field: fieldErr.Field, Reason: fieldErr.Reason})
+			}
+		}
+	}
+
+	if len(response.Fields) == 0 {
+		WriteError(w, err)
+		return
+	}
+
+	writeErrorResponse(w, http.StatusBadRequest, response)
+}
+
+// WriteConflict answers a failed conditional update with the entity as it is now, so the client can reapply its changes.
+func WriteConflict(w http.ResponseWriter, err error, current any, version *int) {
+	SetETag(w, version)
+	writeErrorResponse(w, http.StatusConflict, ErrorResponse{Reason: err.Error(), Code: CodeVersionConflict, Current: current})
+}
+
+// WriteErrorReason sends the error envelope for failures that have no sentinel error, e.g. a malformed path parameter.
+func WriteErrorReason(w http.ResponseWriter, status int, code Code, reason string) {
+	writeErrorResponse(w, status, ErrorResponse{Reason: reason, Code: code})
+}
+
+func writeErrorResponse(w http.ResponseWriter, status int, response ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
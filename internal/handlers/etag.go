@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ETagHeader    = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+// SetETag tags the response with the version of the entity it carries.
+func SetETag(w http.ResponseWriter, version *int) {
+	if version == nil {
+		return
+	}
+	w.Header().Set(ETagHeader, strconv.Quote(strconv.Itoa(*version)))
+}
+
+// ExpectedVersion returns the version a conditional update is made against. It is taken from If-Match
+// or from the request body, nil means the update is unconditional. A bare number is accepted in If-Match as well.
+func ExpectedVersion(r *http.Request, bodyVersion *int) (*int, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get(IfMatchHeader))
+	if ifMatch == "" || ifMatch == "*" {
+		return bodyVersion, nil
+	}
+
+	tag := strings.TrimPrefix(ifMatch, "W/")
+	if unquoted, err := strconv.Unquote(tag); err == nil {
+		tag = unquoted
+	}
+
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return nil, ErrInvalidIfMatch
+	}
+
+	if bodyVersion != nil && *bodyVersion != version {
+		return nil, ErrVersionMismatch
+	}
+
+	return &version, nil
+}
@@ -6,4 +6,7 @@ var (
 	ErrDecodeBody       = errors.New("failed to decode request body")
 	ErrInternal         = errors.New("internal error")
 	ErrInvalidURLParams = errors.New("invalid url params")
+	ErrInvalidCursor    = errors.New("invalid cursor")
+	ErrInvalidIfMatch   = errors.New("If-Match must hold a single version ETag")
+	ErrVersionMismatch  = errors.New("If-Match and the expected version in the body disagree")
 )
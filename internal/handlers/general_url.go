@@ -8,4 +8,7 @@ const (
 var (
 	LimitQueryParam  = "limit"
 	OffsetQueryParam = "offset"
+
+	FromVersionQueryParam = "from"
+	ToVersionQueryParam   = "to"
 )
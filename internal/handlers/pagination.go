@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"avito_intership/internal/model"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	CursorQueryParam = "cursor"
+	TotalQueryParam  = "withTotal"
+)
+
+type cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+// PageResponse is returned by list endpoints once the client opts into cursor pagination.
+type PageResponse[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"nextCursor"`
+	Total      *int    `json:"total,omitempty"`
+}
+
+func NewPageResponse[T any](items []T, pageInfo model.PageInfo) PageResponse[T] {
+	return PageResponse[T]{
+		Items:      items,
+		NextCursor: EncodeCursor(pageInfo.NextCursor),
+		Total:      pageInfo.Total,
+	}
+}
+
+// EncodeCursor turns the cursor into an opaque url-safe token.
+func EncodeCursor(c *model.Cursor) *string {
+	if c == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(cursor{CreatedAt: c.CreatedAt, ID: c.ID})
+	if err != nil {
+		return nil
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(raw)
+	return &encoded
+}
+
+func DecodeCursor(encoded string) (*model.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	c := cursor{}
+	if err = json.Unmarshal(raw, &c); err != nil || c.ID == "" || c.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &model.Cursor{CreatedAt: c.CreatedAt, ID: c.ID}, nil
+}
+
+// GetPageQueryParams builds the requested page on top of the legacy limit and offset.
+// The envelope flag is set when the client sent a cursor (empty for the first page)
+// or asked for the total, otherwise the endpoint keeps answering with a plain array.
+func GetPageQueryParams(values url.Values, limit, offset int) (page model.Page, envelope bool, err error) {
+	page = model.Page{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if values.Has(TotalQueryParam) {
+		if page.WithTotal, err = strconv.ParseBool(values.Get(TotalQueryParam)); err != nil {
+			return model.Page{}, false, ErrInvalidURLParams
+		}
+		envelope = true
+	}
+
+	if values.Has(CursorQueryParam) {
+		envelope = true
+		if encoded := values.Get(CursorQueryParam); encoded != "" {
+			if page.After, err = DecodeCursor(encoded); err != nil {
+				return model.Page{}, false, err
+			}
+		}
+	}
+
+	return page, envelope, nil
+}
@@ -0,0 +1,35 @@
+package handler_supplier_converter
+
+import (
+	handler_bid_converter "avito_intership/internal/handlers/bid/converter"
+	handler_bid_model "avito_intership/internal/handlers/bid/model"
+	handler_supplier_model "avito_intership/internal/handlers/supplier/model"
+	"avito_intership/internal/model"
+)
+
+func ToProfileHandler(profile model.SupplierProfile) handler_supplier_model.ProfileResponse {
+	recent := make([]handler_bid_model.ReviewResponse, 0, len(profile.RecentReviews))
+	for _, feedback := range profile.RecentReviews {
+		recent = append(recent, handler_bid_converter.ToReviewHandler(feedback))
+	}
+
+	return handler_supplier_model.ProfileResponse{
+		AuthorType: profile.Author.Type,
+		AuthorID:   profile.Author.ID,
+		Name:       profile.Name,
+		Bids: handler_supplier_model.BidStatsResponse{
+			Submitted:  profile.BidsSubmitted,
+			Won:        profile.BidsWon,
+			Approvals:  profile.Approvals,
+			Rejections: profile.Rejections,
+		},
+		Reviews: handler_supplier_model.ReviewSummaryResponse{
+			ReviewStatsResponse: handler_bid_model.ReviewStatsResponse{
+				Count:         profile.Reviews.Count,
+				AverageRating: profile.Reviews.AverageRating,
+				Ratings:       profile.Reviews.Ratings,
+			},
+			Recent: recent,
+		},
+	}
+}
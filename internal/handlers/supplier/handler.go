@@ -0,0 +1,9 @@
+package handler_supplier
+
+import (
+	"net/http"
+)
+
+type Handler interface {
+	Profile() http.HandlerFunc
+}
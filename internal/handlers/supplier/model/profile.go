@@ -0,0 +1,23 @@
+package handler_supplier_model
+
+import handler_bid_model "avito_intership/internal/handlers/bid/model"
+
+type BidStatsResponse struct {
+	Submitted  int `json:"submitted"`
+	Won        int `json:"won"`
+	Approvals  int `json:"approvals"`
+	Rejections int `json:"rejections"`
+}
+
+type ReviewSummaryResponse struct {
+	handler_bid_model.ReviewStatsResponse
+	Recent []handler_bid_model.ReviewResponse `json:"recent"`
+}
+
+type ProfileResponse struct {
+	AuthorType string                `json:"author_type"`
+	AuthorID   string                `json:"author_id"`
+	Name       string                `json:"name"`
+	Bids       BidStatsResponse      `json:"bids"`
+	Reviews    ReviewSummaryResponse `json:"reviews"`
+}
@@ -0,0 +1,61 @@
+package handler_supplier_mux_impl
+
+import (
+	"avito_intership/internal/handlers"
+	handler_supplier "avito_intership/internal/handlers/supplier"
+	handler_supplier_converter "avito_intership/internal/handlers/supplier/converter"
+	"avito_intership/internal/middlewares"
+	service_auth "avito_intership/internal/service/auth"
+	service_supplier "avito_intership/internal/service/supplier"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"log/slog"
+	"net/http"
+)
+
+type handler struct {
+	router *mux.Router
+
+	service service_supplier.Service
+
+	logger *slog.Logger
+}
+
+func (h *handler) Profile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authorID := mux.Vars(r)[handler_supplier.AuthorIDUrlPath]
+		if err := uuid.Validate(authorID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid author id")
+			return
+		}
+
+		profile, err := h.service.Profile(r.Context(), authorID)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_supplier_converter.ToProfileHandler(profile)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func Register(router *mux.Router, service service_supplier.Service, authService service_auth.Service, logger *slog.Logger) error {
+	h := &handler{
+		router:  router,
+		service: service,
+		logger:  logger,
+	}
+
+	apiRouter := router.PathPrefix("/api").Subrouter()
+
+	apiRouter.Use(middlewares.Log(h.logger), middlewares.Auth(authService, h.logger))
+
+	apiRouter.Path("/suppliers/{author_id}/profile").Methods(http.MethodGet).Handler(h.Profile())
+
+	return nil
+}
@@ -0,0 +1,5 @@
+package handler_supplier
+
+var (
+	AuthorIDUrlPath = "author_id"
+)
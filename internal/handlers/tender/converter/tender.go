@@ -12,6 +12,47 @@ func ToTenderService(tender handler_tender_model.TenderRequest) model.Tender {
 		ServiceType:     tender.ServiceType,
 		OrganizationID:  tender.OrganizationID,
 		CreatorUsername: tender.CreatorUsername,
+		DecisionPolicy:  ToDecisionPolicyService(tender.DecisionPolicy),
+	}
+}
+
+func EditToTenderService(tender handler_tender_model.TenderEditRequest) model.Tender {
+	return model.Tender{
+		Name:           tender.Name,
+		Description:    tender.Description,
+		ServiceType:    tender.ServiceType,
+		DecisionPolicy: ToDecisionPolicyService(tender.DecisionPolicy),
+	}
+}
+
+func ToDecisionPolicyService(policy *handler_tender_model.DecisionPolicyRequest) *model.DecisionPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	rejectionVeto := true
+	if policy.RejectionVeto != nil {
+		rejectionVeto = *policy.RejectionVeto
+	}
+
+	return &model.DecisionPolicy{
+		Type:              *policy.Type,
+		RequiredApprovals: policy.RequiredApprovals,
+		ApproverID:        policy.ApproverID,
+		RejectionVeto:     rejectionVeto,
+	}
+}
+
+func ToDecisionPolicyHandler(policy *model.DecisionPolicy) *handler_tender_model.DecisionPolicyResponse {
+	if policy == nil {
+		return nil
+	}
+
+	return &handler_tender_model.DecisionPolicyResponse{
+		Type:              policy.Type,
+		RequiredApprovals: policy.RequiredApprovals,
+		ApproverID:        policy.ApproverID,
+		RejectionVeto:     policy.RejectionVeto,
 	}
 }
 
@@ -24,6 +65,11 @@ func ToTenderHandler(tender model.Tender) handler_tender_model.TenderResponse {
 		ServiceType: tender.ServiceType,
 		Version:     tender.Version,
 		CreatedAt:   tender.CreatedAt,
+		ChangedBy:   tender.ChangedBy,
+		ChangeKind:  tender.ChangeKind,
+		Closed:      tender.Closed(),
+
+		DecisionPolicy: ToDecisionPolicyHandler(tender.DecisionPolicy),
 	}
 }
 
@@ -0,0 +1,54 @@
+package handler_tender_converter
+
+import (
+	handler_tender_model "avito_intership/internal/handlers/tender/model"
+	"avito_intership/internal/model"
+)
+
+func ToTenderVersionHandler(version model.TenderVersion) handler_tender_model.TenderVersionResponse {
+	return handler_tender_model.TenderVersionResponse{
+		TenderResponse: ToTenderHandler(version.Tender),
+		ChangedAt:      version.ChangedAt,
+	}
+}
+
+func ArrToTenderVersionHandler(versions []model.TenderVersion) []handler_tender_model.TenderVersionResponse {
+	versionsResp := make([]handler_tender_model.TenderVersionResponse, 0, len(versions))
+	for _, version := range versions {
+		versionsResp = append(versionsResp, ToTenderVersionHandler(version))
+	}
+	return versionsResp
+}
+
+// fieldNames are the JSON names of the tender fields a diff compares
+var fieldNames = map[string]string{
+	model.FieldName:        "name",
+	model.FieldDescription: "description",
+	model.FieldServiceType: "serviceType",
+	model.FieldStatus:      "status",
+
+	model.FieldDecisionPolicyType: "decisionPolicy.type",
+	model.FieldRequiredApprovals:  "decisionPolicy.requiredApprovals",
+	model.FieldApproverID:         "decisionPolicy.approverId",
+	model.FieldRejectionVeto:      "decisionPolicy.rejectionVeto",
+}
+
+func ToVersionDiffHandler(diff model.VersionDiff) handler_tender_model.VersionDiffResponse {
+	changes := make([]handler_tender_model.FieldChangeResponse, 0, len(diff.Changes))
+	for _, change := range diff.Changes {
+		changes = append(changes, handler_tender_model.FieldChangeResponse{
+			Field: fieldNames[change.Field],
+			From:  change.From,
+			To:    change.To,
+		})
+	}
+
+	return handler_tender_model.VersionDiffResponse{
+		FromVersion: diff.From,
+		ToVersion:   diff.To,
+		ChangedAt:   diff.ChangedAt,
+		ChangedBy:   diff.ChangedBy,
+		ChangeKind:  diff.ChangeKind,
+		Changes:     changes,
+	}
+}
@@ -5,11 +5,15 @@ import (
 )
 
 type Handler interface {
-	TenderOrganizationID() http.HandlerFunc
-	TenderList() http.HandlerFunc
-	Create() http.HandlerFunc
-	TendersByUser() http.HandlerFunc
-	TenderStatus() http.HandlerFunc
-	ChangeTenderStatus() http.HandlerFunc
+	Ping() http.HandlerFunc
+	Tenders() http.HandlerFunc
+	CreateTender() http.HandlerFunc
+	TenderByUser() http.HandlerFunc
+	GetStatus() http.HandlerFunc
+	UpdateStatus() http.HandlerFunc
 	Edit() http.HandlerFunc
+	RollbackVersion() http.HandlerFunc
+	Versions() http.HandlerFunc
+	Version() http.HandlerFunc
+	Diff() http.HandlerFunc
 }
@@ -12,12 +12,50 @@ type TenderResponse struct {
 	ServiceType *string    `json:"serviceType"`
 	Version     *int       `json:"version"`
 	CreatedAt   *time.Time `json:"createdAt"`
+	ChangedBy   *string    `json:"changedBy"`
+	ChangeKind  *string    `json:"changeKind"`
+	Closed      bool       `json:"closed"`
+
+	DecisionPolicy *DecisionPolicyResponse `json:"decisionPolicy,omitempty"`
+}
+
+// DecisionPolicyResponse is the rule the bids of the tender are decided by, it is versioned with the tender.
+type DecisionPolicyResponse struct {
+	Type              string  `json:"type"`
+	RequiredApprovals *int    `json:"requiredApprovals,omitempty"`
+	ApproverID        *string `json:"approverId,omitempty"`
+	RejectionVeto     bool    `json:"rejectionVeto"`
+}
+
+// DecisionPolicyRequest sets the decision policy of a tender as a whole. RequiredApprovals is needed by
+// FixedApprovals and ApproverID by SingleApprover. RejectionVeto is on unless it is turned off explicitly.
+type DecisionPolicyRequest struct {
+	Type              *string `json:"type" validate:"required,oneof=Majority Unanimous FixedApprovals SingleApprover"`
+	RequiredApprovals *int    `json:"requiredApprovals" validate:"omitnil,min=1"`
+	ApproverID        *string `json:"approverId" validate:"omitnil,uuid"`
+	RejectionVeto     *bool   `json:"rejectionVeto"`
 }
 
 type TenderRequest struct {
-	Name            *string `json:"name"`
-	Description     *string `json:"description"`
-	ServiceType     *string `json:"serviceType"`
-	OrganizationID  *string `json:"organizationId"`
-	CreatorUsername *string `json:"creatorUsername"`
+	Name            *string `json:"name" validate:"required,min=1,max=100"`
+	Description     *string `json:"description" validate:"required,min=1"`
+	ServiceType     *string `json:"serviceType" validate:"required,oneof=Construction Delivery Manufacture"`
+	OrganizationID  *string `json:"organizationId" validate:"required,uuid"`
+	CreatorUsername *string `json:"creatorUsername" validate:"omitnil,max=100"`
+
+	DecisionPolicy *DecisionPolicyRequest `json:"decisionPolicy"`
 }
+
+// TenderEditRequest carries the content fields of a tender, every one of them is optional.
+// ExpectedVersion makes the edit conditional, the same as the If-Match header.
+type TenderEditRequest struct {
+	Name            *string `json:"name" validate:"omitnil,min=1,max=100"`
+	Description     *string `json:"description" validate:"omitnil,min=1"`
+	ServiceType     *string `json:"serviceType" validate:"omitnil,oneof=Construction Delivery Manufacture"`
+	ExpectedVersion *int    `json:"expectedVersion" validate:"omitnil,min=1"`
+
+	DecisionPolicy *DecisionPolicyRequest `json:"decisionPolicy"`
+}
+
+// TenderStatusTag validates the status query parameter.
+const TenderStatusTag = "required,oneof=Created Published Closed"
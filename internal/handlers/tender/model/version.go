@@ -0,0 +1,23 @@
+package handler_tender_model
+
+import "time"
+
+type TenderVersionResponse struct {
+	TenderResponse
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+type FieldChangeResponse struct {
+	Field string  `json:"field"`
+	From  *string `json:"from"`
+	To    *string `json:"to"`
+}
+
+type VersionDiffResponse struct {
+	FromVersion int                   `json:"fromVersion"`
+	ToVersion   int                   `json:"toVersion"`
+	ChangedAt   time.Time             `json:"changedAt"`
+	ChangedBy   *string               `json:"changedBy"`
+	ChangeKind  *string               `json:"changeKind"`
+	Changes     []FieldChangeResponse `json:"changes"`
+}
@@ -6,10 +6,11 @@ import (
 	handler_tender_converter "avito_intership/internal/handlers/tender/converter"
 	handler_tender_model "avito_intership/internal/handlers/tender/model"
 	"avito_intership/internal/middlewares"
-	repository_tenders "avito_intership/internal/repository/tender"
-	service_employee "avito_intership/internal/service/employee"
-	service_organization_resp "avito_intership/internal/service/organization_responsible"
+	"avito_intership/internal/model"
+	service_auth "avito_intership/internal/service/auth"
+	service_idempotency "avito_intership/internal/service/idempotency"
 	service_tenders "avito_intership/internal/service/tender"
+	"avito_intership/internal/validator"
 	"avito_intership/pkg/logger"
 	"encoding/json"
 	"errors"
@@ -19,6 +20,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 type handler struct {
@@ -26,6 +28,8 @@ type handler struct {
 
 	service service_tenders.Service
 
+	validator *validator.Validate
+
 	logger *slog.Logger
 }
 
@@ -59,6 +63,40 @@ func (h *handler) getLimitAndOffsetQueryParams(limitStr, offsetStr string) (limi
 	return limit, offset
 }
 
+func (h *handler) getTenderFilterQueryParams(values url.Values) (model.TenderFilter, error) {
+	filter := model.TenderFilter{
+		Query:        values.Get(handler_tender.SearchQueryParam),
+		ServiceTypes: values[handler_tender.ServiceTypeQueryParam],
+		Statuses:     values[handler_tender.StatusQueryParam],
+		Sort:         values.Get(handler_tender.SortQueryParam),
+	}
+
+	if organizationID := values.Get(handler_tender.OrganizationIDQueryParam); organizationID != "" {
+		if err := uuid.Validate(organizationID); err != nil {
+			return model.TenderFilter{}, errors.New("invalid organization_id")
+		}
+		filter.OrganizationID = organizationID
+	}
+
+	if createdFrom := values.Get(handler_tender.CreatedFromQueryParam); createdFrom != "" {
+		t, err := time.Parse(time.RFC3339, createdFrom)
+		if err != nil {
+			return model.TenderFilter{}, errors.New("invalid created_from, expected RFC 3339")
+		}
+		filter.CreatedFrom = &t
+	}
+
+	if createdTo := values.Get(handler_tender.CreatedToQueryParam); createdTo != "" {
+		t, err := time.Parse(time.RFC3339, createdTo)
+		if err != nil {
+			return model.TenderFilter{}, errors.New("invalid created_to, expected RFC 3339")
+		}
+		filter.CreatedTo = &t
+	}
+
+	return filter, nil
+}
+
 func (h *handler) Ping() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -69,41 +107,44 @@ func (h *handler) Tenders() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		l := logger.EndToEndLogging(r.Context(), h.logger)
 
-		if err := r.ParseForm(); err != nil {
-			l.Error("Failed to parse form")
-			http.Error(w, "invalid requested url", http.StatusBadRequest)
+		values, err := h.parseURL(r.RequestURI, l)
+		if err != nil {
+			handlers.WriteError(w, err)
 			return
 		}
 
-		values, err := h.parseURL(r.RequestURI, l)
+		limit, offset := h.getLimitAndOffsetQueryParams(values.Get(handlers.LimitQueryParam), values.Get(handlers.OffsetQueryParam))
+
+		filter, err := h.getTenderFilterQueryParams(values)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, err.Error())
+			return
 		}
 
-		limit, offset := h.getLimitAndOffsetQueryParams(values.Get(handlers.LimitQueryParam), values.Get(handlers.OffsetQueryParam))
+		page, envelope, err := handlers.GetPageQueryParams(values, limit, offset)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
 
-		tenders, err := h.service.TenderList(r.Context(), r.Form[handler_tender.ServiceTypeQueryParam], limit, offset)
+		tenders, pageInfo, err := h.service.TenderList(r.Context(), filter, page)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_tenders.ErrNoTenders):
+			if errors.Is(err, service_tenders.ErrNoTenders) {
 				w.WriteHeader(http.StatusNoContent)
 				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
 			}
+			handlers.WriteError(w, err)
+			return
+		}
+
+		var response interface{} = handler_tender_converter.ArrToTenderHandler(tenders)
+		if envelope {
+			response = handlers.NewPageResponse(handler_tender_converter.ArrToTenderHandler(tenders), pageInfo)
 		}
 
 		w.Header().Add("Content-Type", "application/json")
-		if err = json.NewEncoder(w).Encode(handler_tender_converter.ArrToTenderHandler(tenders)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err = json.NewEncoder(w).Encode(response); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -115,20 +156,26 @@ func (h *handler) CreateTender() http.HandlerFunc {
 		tenderReq := handler_tender_model.TenderRequest{}
 		if err := json.NewDecoder(r.Body).Decode(&tenderReq); err != nil {
 			l.Error("Failed to decode body", "error", err.Error())
-			http.Error(w, handlers.ErrDecodeBody.Error(), http.StatusBadRequest)
+			handlers.WriteError(w, handlers.ErrDecodeBody)
+			return
+		}
+
+		if err := h.validator.Validate(tenderReq); err != nil {
+			handlers.WriteValidationError(w, err)
 			return
 		}
 
 		tender, err := h.service.Create(r.Context(), handler_tender_converter.ToTenderService(tenderReq))
 		if err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, err)
 			return
 		}
 
+		handlers.SetETag(w, tender.Version)
 		w.Header().Add("Content-Type", "application/json")
 		encoder := json.NewEncoder(w)
 		if err = encoder.Encode(handler_tender_converter.ToTenderHandler(tender)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -140,39 +187,36 @@ func (h *handler) TenderByUser() http.HandlerFunc {
 
 		values, err := h.parseURL(r.RequestURI, l)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
 		limit, offset := h.getLimitAndOffsetQueryParams(values.Get(handlers.LimitQueryParam), values.Get(handlers.OffsetQueryParam))
 
-		username := values.Get(handler_tender.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, "provide username", http.StatusBadRequest)
+		page, envelope, err := handlers.GetPageQueryParams(values, limit, offset)
+		if err != nil {
+			handlers.WriteError(w, err)
 			return
 		}
 
-		tenders, err := h.service.TendersByUser(r.Context(), username, limit, offset)
+		tenders, pageInfo, err := h.service.TendersByUser(r.Context(), page)
 		if err != nil {
-			switch {
-			case errors.Is(err, repository_tenders.ErrNoTenders):
+			if errors.Is(err, service_tenders.ErrNoTenders) {
 				w.WriteHeader(http.StatusNoContent)
 				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
 			}
+			handlers.WriteError(w, err)
+			return
+		}
+
+		var response interface{} = handler_tender_converter.ArrToTenderHandler(tenders)
+		if envelope {
+			response = handlers.NewPageResponse(handler_tender_converter.ArrToTenderHandler(tenders), pageInfo)
 		}
 
 		w.Header().Add("Content-Type", "application/json")
-		if err = json.NewEncoder(w).Encode(handler_tender_converter.ArrToTenderHandler(tenders)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err = json.NewEncoder(w).Encode(response); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -182,24 +226,19 @@ func (h *handler) GetStatus() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		tenderID := mux.Vars(r)[handler_tender.TenderIDUrlPath]
 		if err := uuid.Validate(tenderID); err != nil {
-			http.Error(w, "invalid tender id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid tender id")
 			return
 		}
 
 		_, status, err := h.service.TenderStatus(r.Context(), tenderID)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_tenders.ErrNoTenders):
-				http.Error(w, "invalid tender id", http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
+		w.Header().Add("Content-Type", "text/plain; charset=utf-8")
 		if _, err = w.Write([]byte(status)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -211,52 +250,32 @@ func (h *handler) UpdateStatus() http.HandlerFunc {
 
 		tenderID := mux.Vars(r)[handler_tender.TenderIDUrlPath]
 		if err := uuid.Validate(tenderID); err != nil {
-			http.Error(w, "invalid tender id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid tender id")
 			return
 		}
 
 		values, err := h.parseURL(r.RequestURI, l)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
-		}
-
-		username := values.Get(handler_tender.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, "provide username", http.StatusBadRequest)
+			handlers.WriteError(w, err)
 			return
 		}
 
 		status := values.Get(handler_tender.StatusQueryParam)
-		if username == "" {
-			http.Error(w, "provide status", http.StatusBadRequest)
+		if err = h.validator.Var(handler_tender.StatusQueryParam, status, handler_tender_model.TenderStatusTag); err != nil {
+			handlers.WriteValidationError(w, err)
 			return
 		}
 
-		tender, err := h.service.ChangeTenderStatusWithUserCheck(r.Context(), tenderID, username, status)
+		tender, err := h.service.ChangeTenderStatusWithUserCheck(r.Context(), tenderID, status)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_tenders.ErrInvalidStatus):
-				http.Error(w, "invalid status", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_tenders.ErrNoTenders):
-				http.Error(w, "invalid tender id", http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
+		handlers.SetETag(w, tender.Version)
 		w.Header().Add("Content-Type", "application/json")
 		if err = json.NewEncoder(w).Encode(handler_tender_converter.ToTenderHandler(tender)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -268,59 +287,42 @@ func (h *handler) Edit() http.HandlerFunc {
 
 		tenderID := mux.Vars(r)[handler_tender.TenderIDUrlPath]
 		if err := uuid.Validate(tenderID); err != nil {
-			http.Error(w, "invalid tender id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid tender id")
 			return
 		}
 
-		tenderReq := handler_tender_model.TenderRequest{}
+		tenderReq := handler_tender_model.TenderEditRequest{}
 		if err := json.NewDecoder(r.Body).Decode(&tenderReq); err != nil {
 			l.Error("Failed to decode body", "error", err.Error())
-			http.Error(w, handlers.ErrDecodeBody.Error(), http.StatusBadRequest)
+			handlers.WriteError(w, handlers.ErrDecodeBody)
 			return
 		}
 
-		values, err := h.parseURL(r.RequestURI, l)
-		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+		if err := h.validator.Validate(tenderReq); err != nil {
+			handlers.WriteValidationError(w, err)
+			return
 		}
 
-		username := values.Get(handler_tender.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, "provide username", http.StatusBadRequest)
+		expectedVersion, err := handlers.ExpectedVersion(r, tenderReq.ExpectedVersion)
+		if err != nil {
+			handlers.WriteError(w, err)
 			return
 		}
 
-		tender, err := h.service.Edit(r.Context(), tenderID, username, handler_tender_converter.ToTenderService(tenderReq))
+		tender, err := h.service.Edit(r.Context(), tenderID, handler_tender_converter.EditToTenderService(tenderReq), expectedVersion)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_tenders.ErrForbidden):
-				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
-				return
-			case errors.Is(err, service_tenders.ErrNoTenders):
-				http.Error(w, "invalid tender id", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_employee.ErrNonExistingEmployee):
-				http.Error(w, "invalid username", http.StatusUnauthorized)
-				return
-			case errors.Is(err, service_organization_resp.ErrUserHasNoOrganization):
-				http.Error(w, "user has no organization", http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			if errors.Is(err, service_tenders.ErrVersionConflict) {
+				handlers.WriteConflict(w, err, handler_tender_converter.ToTenderHandler(tender), tender.Version)
 				return
 			}
+			handlers.WriteError(w, err)
+			return
 		}
 
+		handlers.SetETag(w, tender.Version)
 		w.Header().Add("Content-Type", "application/json")
 		if err = json.NewEncoder(w).Encode(handler_tender_converter.ToTenderHandler(tender)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
@@ -328,78 +330,137 @@ func (h *handler) Edit() http.HandlerFunc {
 
 func (h *handler) RollbackVersion() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		l := logger.EndToEndLogging(r.Context(), h.logger)
+		tenderID := mux.Vars(r)[handler_tender.TenderIDUrlPath]
+		if err := uuid.Validate(tenderID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid tender id")
+			return
+		}
+
+		versionStr := mux.Vars(r)[handler_tender.VersionPath]
+		if versionStr == "" {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid versionStr")
+			return
+		}
+
+		version, err := strconv.Atoi(versionStr)
+		if err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid versionStr")
+			return
+		}
+
+		tender, err := h.service.RollbackVersion(r.Context(), tenderID, version)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		handlers.SetETag(w, tender.Version)
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_tender_converter.ToTenderHandler(tender)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
 
+func (h *handler) Versions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		tenderID := mux.Vars(r)[handler_tender.TenderIDUrlPath]
 		if err := uuid.Validate(tenderID); err != nil {
-			http.Error(w, "invalid tender id", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid tender id")
 			return
 		}
 
-		values, err := h.parseURL(r.RequestURI, l)
+		versions, err := h.service.Versions(r.Context(), tenderID)
 		if err != nil {
-			switch {
-			case errors.Is(err, handlers.ErrInvalidURLParams):
-				http.Error(w, handlers.ErrInvalidURLParams.Error(), http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
 		}
 
-		versionStr := mux.Vars(r)[handler_tender.VersionPath]
-		if versionStr == "" {
-			http.Error(w, "invalid versionStr", http.StatusBadRequest)
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_tender_converter.ArrToTenderVersionHandler(versions)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Version() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenderID := mux.Vars(r)[handler_tender.TenderIDUrlPath]
+		if err := uuid.Validate(tenderID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid tender id")
 			return
 		}
 
-		version, err := strconv.Atoi(versionStr)
+		version, err := strconv.Atoi(mux.Vars(r)[handler_tender.VersionPath])
 		if err != nil {
-			http.Error(w, "invalid versionStr", http.StatusBadRequest)
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid version")
 			return
 		}
 
-		username := values.Get(handler_tender.UsernameQueryParam)
-		if username == "" {
-			http.Error(w, "provide username", http.StatusBadRequest)
+		tenderVersion, err := h.service.Version(r.Context(), tenderID, version)
+		if err != nil {
+			handlers.WriteError(w, err)
 			return
 		}
 
-		tender, err := h.service.RollbackVersion(r.Context(), tenderID, username, version)
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_tender_converter.ToTenderVersionHandler(tenderVersion)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Diff() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		tenderID := mux.Vars(r)[handler_tender.TenderIDUrlPath]
+		if err := uuid.Validate(tenderID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid tender id")
+			return
+		}
+
+		values, err := h.parseURL(r.RequestURI, l)
 		if err != nil {
-			switch {
-			case errors.Is(err, service_employee.ErrNonExistingEmployee):
-				http.Error(w, "user doesn't exists", http.StatusUnauthorized)
-				return
-			case errors.Is(err, service_tenders.ErrNoTenders):
-				http.Error(w, "invalid version or tender id", http.StatusBadRequest)
-				return
-			case errors.Is(err, service_tenders.ErrForbidden):
-				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
-				return
-			case errors.Is(err, service_organization_resp.ErrUserHasNoOrganization):
-				http.Error(w, "user has no organization", http.StatusBadRequest)
-				return
-			default:
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
+			handlers.WriteError(w, err)
+			return
+		}
+
+		from, err := strconv.Atoi(values.Get(handlers.FromVersionQueryParam))
+		if err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid from version")
+			return
+		}
+
+		to, err := strconv.Atoi(values.Get(handlers.ToVersionQueryParam))
+		if err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid to version")
+			return
+		}
+
+		diff, err := h.service.Diff(r.Context(), tenderID, from, to)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
 		}
 
 		w.Header().Add("Content-Type", "application/json")
-		if err = json.NewEncoder(w).Encode(handler_tender_converter.ToTenderHandler(tender)); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err = json.NewEncoder(w).Encode(handler_tender_converter.ToVersionDiffHandler(diff)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
 			return
 		}
 	}
 }
 
-func Register(router *mux.Router, service service_tenders.Service, logger *slog.Logger) error {
+func Register(router *mux.Router, service service_tenders.Service, authService service_auth.Service, idempotencyService service_idempotency.Service, logger *slog.Logger) error {
 	h := &handler{
-		router:  router,
-		service: service,
-		logger:  logger,
+		router:    router,
+		service:   service,
+		validator: validator.New(),
+		logger:    logger,
 	}
 
 	apiRouter := router.PathPrefix("/api").Subrouter()
@@ -408,13 +469,28 @@ func Register(router *mux.Router, service service_tenders.Service, logger *slog.
 
 	apiRouter.Path("/ping").Methods(http.MethodGet).Handler(h.Ping())
 
-	apiRouter.Path("/tenders").Methods(http.MethodGet).Handler(h.Tenders())
-	apiRouter.Path("/tenders/new").Methods(http.MethodPost).Handler(h.CreateTender())
-	apiRouter.Path("/tenders/my").Methods(http.MethodGet).Handler(h.TenderByUser())
-	apiRouter.Path("/tenders/{tender_id}/status").Methods(http.MethodGet).Handler(h.GetStatus())
-	apiRouter.Path("/tenders/{tender_id}/status").Methods(http.MethodPut).Handler(h.UpdateStatus())
-	apiRouter.Path("/tenders/{tender_id}/edit").Methods(http.MethodPatch).Handler(h.Edit())
-	apiRouter.Path("/tenders/{tender_id}/rollback/{version}").Methods(http.MethodPut).Handler(h.RollbackVersion())
+	//PUBLIC ROUTES. A TOKEN IS OPTIONAL AND ONLY WIDENS WHAT THE CALLER CAN SEE
+	publicRouter := apiRouter.NewRoute().Subrouter()
+
+	publicRouter.Use(middlewares.OptionalAuth(authService, h.logger))
+
+	publicRouter.Path("/tenders").Methods(http.MethodGet).Handler(h.Tenders())
+	publicRouter.Path("/tenders/{tender_id}/status").Methods(http.MethodGet).Handler(h.GetStatus())
+
+	protectedRouter := apiRouter.NewRoute().Subrouter()
+
+	protectedRouter.Use(middlewares.Auth(authService, h.logger))
+
+	idempotent := middlewares.Idempotency(idempotencyService, h.logger)
+
+	protectedRouter.Path("/tenders/new").Methods(http.MethodPost).Handler(idempotent(h.CreateTender()))
+	protectedRouter.Path("/tenders/my").Methods(http.MethodGet).Handler(h.TenderByUser())
+	protectedRouter.Path("/tenders/{tender_id}/status").Methods(http.MethodPut).Handler(h.UpdateStatus())
+	protectedRouter.Path("/tenders/{tender_id}/edit").Methods(http.MethodPatch).Handler(h.Edit())
+	protectedRouter.Path("/tenders/{tender_id}/rollback/{version}").Methods(http.MethodPut).Handler(h.RollbackVersion())
+	protectedRouter.Path("/tenders/{tender_id}/versions").Methods(http.MethodGet).Handler(h.Versions())
+	protectedRouter.Path("/tenders/{tender_id}/versions/{version}").Methods(http.MethodGet).Handler(h.Version())
+	protectedRouter.Path("/tenders/{tender_id}/diff").Methods(http.MethodGet).Handler(h.Diff())
 
 	return nil
 }
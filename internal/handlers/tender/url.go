@@ -1,9 +1,13 @@
 package handler_tender
 
 var (
-	UsernameQueryParam    = "username"
-	StatusQueryParam      = "status"
-	ServiceTypeQueryParam = "service_type"
+	StatusQueryParam         = "status"
+	ServiceTypeQueryParam    = "service_type"
+	SearchQueryParam         = "q"
+	OrganizationIDQueryParam = "organization_id"
+	CreatedFromQueryParam    = "created_from"
+	CreatedToQueryParam      = "created_to"
+	SortQueryParam           = "sort"
 )
 
 var (
@@ -0,0 +1,82 @@
+package handler_webhook_converter
+
+import (
+	handler_webhook_model "avito_intership/internal/handlers/webhook/model"
+	"avito_intership/internal/model"
+)
+
+func ToWebhookService(webhook handler_webhook_model.WebhookRequest) model.WebhookSubscription {
+	subscription := model.WebhookSubscription{
+		URL:        *webhook.URL,
+		EventTypes: webhook.EventTypes,
+	}
+	if webhook.OrganizationID != nil {
+		subscription.OrganizationID = *webhook.OrganizationID
+	}
+	return subscription
+}
+
+func EditToWebhookService(webhook handler_webhook_model.WebhookEditRequest) model.WebhookSubscriptionPatch {
+	return model.WebhookSubscriptionPatch{
+		URL:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		Active:     webhook.Active,
+	}
+}
+
+func ToWebhookHandler(subscription model.WebhookSubscription) handler_webhook_model.WebhookResponse {
+	eventTypes := subscription.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return handler_webhook_model.WebhookResponse{
+		ID:             subscription.ID,
+		OrganizationID: subscription.OrganizationID,
+		URL:            subscription.URL,
+		EventTypes:     eventTypes,
+		Active:         subscription.Active,
+		FailureCount:   subscription.FailureCount,
+		DisabledAt:     subscription.DisabledAt,
+		CreatedAt:      subscription.CreatedAt,
+		UpdatedAt:      subscription.UpdatedAt,
+	}
+}
+
+func ToWebhookSecretHandler(subscription model.WebhookSubscription) handler_webhook_model.WebhookSecretResponse {
+	return handler_webhook_model.WebhookSecretResponse{
+		WebhookResponse: ToWebhookHandler(subscription),
+		Secret:          subscription.Secret,
+	}
+}
+
+func ArrToWebhookHandler(subscriptions []model.WebhookSubscription) []handler_webhook_model.WebhookResponse {
+	webhookResp := make([]handler_webhook_model.WebhookResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		webhookResp = append(webhookResp, ToWebhookHandler(subscription))
+	}
+	return webhookResp
+}
+
+func ToDeliveryHandler(delivery model.WebhookDelivery) handler_webhook_model.DeliveryResponse {
+	return handler_webhook_model.DeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func ArrToDeliveryHandler(deliveries []model.WebhookDelivery) []handler_webhook_model.DeliveryResponse {
+	deliveryResp := make([]handler_webhook_model.DeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryResp = append(deliveryResp, ToDeliveryHandler(delivery))
+	}
+	return deliveryResp
+}
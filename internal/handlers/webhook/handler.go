@@ -0,0 +1,16 @@
+package handler_webhook
+
+import (
+	"net/http"
+)
+
+type Handler interface {
+	Create() http.HandlerFunc
+	Webhooks() http.HandlerFunc
+	Webhook() http.HandlerFunc
+	Edit() http.HandlerFunc
+	Delete() http.HandlerFunc
+	RotateSecret() http.HandlerFunc
+	Deliveries() http.HandlerFunc
+	Replay() http.HandlerFunc
+}
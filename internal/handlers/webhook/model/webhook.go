@@ -0,0 +1,52 @@
+package handler_webhook_model
+
+import "time"
+
+// WebhookRequest registers an endpoint. OrganizationID may be left out by a caller that represents one organization only.
+type WebhookRequest struct {
+	OrganizationID *string  `json:"organizationId" validate:"omitnil,uuid"`
+	URL            *string  `json:"url" validate:"required,http_url,max=2048"`
+	EventTypes     []string `json:"eventTypes" validate:"omitempty,dive,oneof=tender.published tender.closed bid.submitted bid.decision_submitted"`
+}
+
+// WebhookEditRequest carries the fields of a subscription to change, every one of them is optional.
+// Setting active to true revives a subscription disabled after too many failures.
+type WebhookEditRequest struct {
+	URL        *string   `json:"url" validate:"omitnil,http_url,max=2048"`
+	EventTypes *[]string `json:"eventTypes" validate:"omitnil,dive,oneof=tender.published tender.closed bid.submitted bid.decision_submitted"`
+	Active     *bool     `json:"active"`
+}
+
+type WebhookResponse struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organizationId"`
+	URL            string     `json:"url"`
+	EventTypes     []string   `json:"eventTypes"`
+	Active         bool       `json:"active"`
+	FailureCount   int        `json:"failureCount"`
+	DisabledAt     *time.Time `json:"disabledAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// WebhookSecretResponse is returned when the secret is generated, it is never shown again.
+type WebhookSecretResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type DeliveryResponse struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"eventId"`
+	EventType      string     `json:"eventType"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt"`
+	LastStatusCode *int       `json:"lastStatusCode"`
+	LastError      *string    `json:"lastError"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+}
+
+// DeliveryStatusTag validates the status query parameter of the delivery log.
+const DeliveryStatusTag = "omitempty,oneof=Pending Delivered Failed"
@@ -0,0 +1,316 @@
+package handler_webhook_mux_impl
+
+import (
+	"avito_intership/internal/handlers"
+	handler_webhook "avito_intership/internal/handlers/webhook"
+	handler_webhook_converter "avito_intership/internal/handlers/webhook/converter"
+	handler_webhook_model "avito_intership/internal/handlers/webhook/model"
+	"avito_intership/internal/middlewares"
+	service_auth "avito_intership/internal/service/auth"
+	service_webhook "avito_intership/internal/service/webhook"
+	"avito_intership/internal/validator"
+	"avito_intership/pkg/logger"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type handler struct {
+	router *mux.Router
+
+	service service_webhook.Service
+
+	validator *validator.Validate
+
+	logger *slog.Logger
+}
+
+func (h *handler) parseURL(requestedURI string, l *slog.Logger) (url.Values, error) {
+	u, err := url.Parse(requestedURI)
+	if err != nil {
+		l.Error("Failed to parse request URI", slog.String("error", err.Error()))
+		return nil, handlers.ErrInternal
+	}
+
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		l.Error("Failed to parse query parameters", slog.String("error", err.Error()))
+		return nil, handlers.ErrInvalidURLParams
+	}
+
+	return values, nil
+}
+
+func (h *handler) getLimitAndOffsetQueryParams(limitStr, offsetStr string) (limit, offset int) {
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limitStr == "" {
+		limit = handlers.DefaultLimit
+	}
+
+	offset, err = strconv.Atoi(offsetStr)
+	if err != nil || offsetStr == "" {
+		offset = handlers.DefaultOffset
+	}
+
+	return limit, offset
+}
+
+func (h *handler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		webhookReq := handler_webhook_model.WebhookRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&webhookReq); err != nil {
+			l.Error("Failed to decode body", "error", err.Error())
+			handlers.WriteError(w, handlers.ErrDecodeBody)
+			return
+		}
+
+		if err := h.validator.Validate(webhookReq); err != nil {
+			handlers.WriteValidationError(w, err)
+			return
+		}
+
+		subscription, err := h.service.Create(r.Context(), handler_webhook_converter.ToWebhookService(webhookReq))
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err = json.NewEncoder(w).Encode(handler_webhook_converter.ToWebhookSecretHandler(subscription)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Webhooks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subscriptions, err := h.service.Subscriptions(r.Context())
+		if err != nil {
+			if errors.Is(err, service_webhook.ErrNoSubscription) {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_webhook_converter.ArrToWebhookHandler(subscriptions)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Webhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhookID := mux.Vars(r)[handler_webhook.WebhookIDUrlPath]
+		if err := uuid.Validate(webhookID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid webhook id")
+			return
+		}
+
+		subscription, err := h.service.Subscription(r.Context(), webhookID)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_webhook_converter.ToWebhookHandler(subscription)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Edit() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		webhookID := mux.Vars(r)[handler_webhook.WebhookIDUrlPath]
+		if err := uuid.Validate(webhookID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid webhook id")
+			return
+		}
+
+		webhookReq := handler_webhook_model.WebhookEditRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&webhookReq); err != nil {
+			l.Error("Failed to decode body", "error", err.Error())
+			handlers.WriteError(w, handlers.ErrDecodeBody)
+			return
+		}
+
+		if err := h.validator.Validate(webhookReq); err != nil {
+			handlers.WriteValidationError(w, err)
+			return
+		}
+
+		subscription, err := h.service.Update(r.Context(), webhookID, handler_webhook_converter.EditToWebhookService(webhookReq))
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_webhook_converter.ToWebhookHandler(subscription)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhookID := mux.Vars(r)[handler_webhook.WebhookIDUrlPath]
+		if err := uuid.Validate(webhookID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid webhook id")
+			return
+		}
+
+		if err := h.service.Delete(r.Context(), webhookID); err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *handler) RotateSecret() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhookID := mux.Vars(r)[handler_webhook.WebhookIDUrlPath]
+		if err := uuid.Validate(webhookID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid webhook id")
+			return
+		}
+
+		subscription, err := h.service.RotateSecret(r.Context(), webhookID)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(handler_webhook_converter.ToWebhookSecretHandler(subscription)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Deliveries() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.EndToEndLogging(r.Context(), h.logger)
+
+		webhookID := mux.Vars(r)[handler_webhook.WebhookIDUrlPath]
+		if err := uuid.Validate(webhookID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid webhook id")
+			return
+		}
+
+		values, err := h.parseURL(r.RequestURI, l)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		status := values.Get(handler_webhook.StatusQueryParam)
+		if err = h.validator.Var(handler_webhook.StatusQueryParam, status, handler_webhook_model.DeliveryStatusTag); err != nil {
+			handlers.WriteValidationError(w, err)
+			return
+		}
+
+		limit, offset := h.getLimitAndOffsetQueryParams(values.Get(handlers.LimitQueryParam), values.Get(handlers.OffsetQueryParam))
+
+		page, envelope, err := handlers.GetPageQueryParams(values, limit, offset)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		deliveries, pageInfo, err := h.service.Deliveries(r.Context(), webhookID, status, page)
+		if err != nil {
+			if errors.Is(err, service_webhook.ErrNoDelivery) {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			handlers.WriteError(w, err)
+			return
+		}
+
+		var response interface{} = handler_webhook_converter.ArrToDeliveryHandler(deliveries)
+		if envelope {
+			response = handlers.NewPageResponse(handler_webhook_converter.ArrToDeliveryHandler(deliveries), pageInfo)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(response); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func (h *handler) Replay() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhookID := mux.Vars(r)[handler_webhook.WebhookIDUrlPath]
+		if err := uuid.Validate(webhookID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid webhook id")
+			return
+		}
+
+		deliveryID := mux.Vars(r)[handler_webhook.DeliveryIDUrlPath]
+		if err := uuid.Validate(deliveryID); err != nil {
+			handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "invalid delivery id")
+			return
+		}
+
+		delivery, err := h.service.Replay(r.Context(), webhookID, deliveryID)
+		if err != nil {
+			handlers.WriteError(w, err)
+			return
+		}
+
+		//THE DELIVERY IS ONLY QUEUED, THE WORKER SENDS IT
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err = json.NewEncoder(w).Encode(handler_webhook_converter.ToDeliveryHandler(delivery)); err != nil {
+			handlers.WriteError(w, handlers.ErrInternal)
+			return
+		}
+	}
+}
+
+func Register(router *mux.Router, service service_webhook.Service, authService service_auth.Service, logger *slog.Logger) error {
+	h := &handler{
+		router:    router,
+		service:   service,
+		validator: validator.New(),
+		logger:    logger,
+	}
+
+	apiRouter := router.PathPrefix("/api").Subrouter()
+
+	apiRouter.Use(middlewares.Log(h.logger), middlewares.Auth(authService, h.logger))
+
+	apiRouter.Path("/webhooks").Methods(http.MethodPost).Handler(h.Create())
+	apiRouter.Path("/webhooks").Methods(http.MethodGet).Handler(h.Webhooks())
+	apiRouter.Path("/webhooks/{webhook_id}").Methods(http.MethodGet).Handler(h.Webhook())
+	apiRouter.Path("/webhooks/{webhook_id}").Methods(http.MethodPatch).Handler(h.Edit())
+	apiRouter.Path("/webhooks/{webhook_id}").Methods(http.MethodDelete).Handler(h.Delete())
+	apiRouter.Path("/webhooks/{webhook_id}/rotate_secret").Methods(http.MethodPost).Handler(h.RotateSecret())
+	apiRouter.Path("/webhooks/{webhook_id}/deliveries").Methods(http.MethodGet).Handler(h.Deliveries())
+	apiRouter.Path("/webhooks/{webhook_id}/deliveries/{delivery_id}/replay").Methods(http.MethodPost).Handler(h.Replay())
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package handler_webhook
+
+var (
+	StatusQueryParam = "status"
+)
+
+var (
+	WebhookIDUrlPath  = "webhook_id"
+	DeliveryIDUrlPath = "delivery_id"
+)
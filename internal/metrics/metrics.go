@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+const namespace = "tender_service"
+
+var (
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of handled HTTP requests by route template, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route template and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+)
+
+var (
+	TendersCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tenders_created_total",
+		Help:      "Number of created tenders.",
+	})
+
+	TendersPublished = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tenders_published_total",
+		Help:      "Number of tenders moved to the Published status.",
+	})
+
+	TendersClosedByQuorum = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tenders_closed_by_quorum_total",
+		Help:      "Number of tenders closed because a bid was accepted under the tender decision policy.",
+	})
+
+	BidsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bids_created_total",
+		Help:      "Number of created bids.",
+	})
+
+	DecisionsSubmitted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "decisions_submitted_total",
+		Help:      "Number of submitted bid decisions by outcome.",
+	}, []string{"decision"})
+
+	EventsDelivered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_delivered_total",
+		Help:      "Number of domain event deliveries by sink and result.",
+	}, []string{"sink", "result"})
+)
+
+// Handler exposes every registered collector in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
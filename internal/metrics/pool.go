@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.canceledAcquireCount
+	ch <- c.emptyAcquireCount
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+}
+
+func newPoolDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+}
+
+// RegisterPool exposes the statistics of the pgx pool shared by the repositories.
+func RegisterPool(pool *pgxpool.Pool) error {
+	c := &poolCollector{
+		pool:                 pool,
+		acquiredConns:        newPoolDesc("acquired_conns", "Number of connections currently acquired from the pool."),
+		idleConns:            newPoolDesc("idle_conns", "Number of idle connections in the pool."),
+		constructingConns:    newPoolDesc("constructing_conns", "Number of connections being established."),
+		totalConns:           newPoolDesc("total_conns", "Total number of connections in the pool."),
+		maxConns:             newPoolDesc("max_conns", "Maximum size of the pool."),
+		acquireCount:         newPoolDesc("acquire_total", "Number of successful acquires from the pool."),
+		acquireDuration:      newPoolDesc("acquire_duration_seconds_total", "Total time spent waiting for a connection."),
+		canceledAcquireCount: newPoolDesc("canceled_acquire_total", "Number of acquires canceled by a context."),
+		emptyAcquireCount:    newPoolDesc("empty_acquire_total", "Number of acquires that had to wait for a connection."),
+	}
+
+	return prometheus.Register(c)
+}
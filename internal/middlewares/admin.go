@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"avito_intership/internal/handlers"
+	"crypto/subtle"
+	"net/http"
+)
+
+const AdminTokenHeader = "X-Admin-Token"
+
+// Admin lets through only requests carrying the configured admin token in X-Admin-Token.
+func Admin(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := r.Header.Get(AdminTokenHeader)
+			if provided == "" {
+				handlers.WriteErrorReason(w, http.StatusUnauthorized, handlers.CodeUnauthenticated, "provide admin token")
+				return
+			}
+
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				handlers.WriteErrorReason(w, http.StatusForbidden, handlers.CodeForbidden, "invalid admin token")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
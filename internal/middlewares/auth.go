@@ -0,0 +1,58 @@
+package middlewares
+
+import (
+	"avito_intership/internal/auth"
+	"avito_intership/internal/handlers"
+	service_auth "avito_intership/internal/service/auth"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const bearerPrefix = "Bearer "
+
+// Auth rejects requests without a valid bearer token and stores the caller identity in the request context.
+func Auth(authService service_auth.Service, l *slog.Logger) func(http.Handler) http.Handler {
+	return authenticate(authService, l, true)
+}
+
+// OptionalAuth lets anonymous requests through and stores the caller identity when a bearer token is given.
+// A token that is present but invalid is still rejected.
+func OptionalAuth(authService service_auth.Service, l *slog.Logger) func(http.Handler) http.Handler {
+	return authenticate(authService, l, false)
+}
+
+func authenticate(authService service_auth.Service, l *slog.Logger, required bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if !strings.HasPrefix(header, bearerPrefix) {
+				if !required {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				handlers.WriteErrorReason(w, http.StatusUnauthorized, handlers.CodeUnauthenticated, "provide bearer token")
+				return
+			}
+
+			identity, err := authService.Authenticate(r.Context(), strings.TrimPrefix(header, bearerPrefix))
+			if err != nil {
+				switch {
+				case errors.Is(err, service_auth.ErrInvalidToken):
+					w.Header().Set("WWW-Authenticate", "Bearer")
+					handlers.WriteError(w, err)
+					return
+				default:
+					l.Error("Failed to authenticate request", "error", err.Error())
+					handlers.WriteError(w, handlers.ErrInternal)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+		})
+	}
+}
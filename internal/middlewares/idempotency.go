@@ -0,0 +1,105 @@
+package middlewares
+
+import (
+	"avito_intership/internal/auth"
+	"avito_intership/internal/handlers"
+	service_idempotency "avito_intership/internal/service/idempotency"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// Idempotency replays the stored response when an authenticated request is retried with the same Idempotency-Key.
+// The key is scoped to the caller, reusing it for a different request is rejected.
+// Responses with 5xx are not stored so the client can retry them. The replay carries the headers the handler set, ETag included.
+func Idempotency(service service_idempotency.Service, l *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			identity, ok := auth.IdentityFromContext(r.Context())
+			if key == "" || !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				handlers.WriteErrorReason(w, http.StatusBadRequest, handlers.CodeInvalidParameter, "Idempotency-Key must be at most 255 characters long")
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				handlers.WriteError(w, handlers.ErrDecodeBody)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			//THE FINGERPRINT COVERS THE TARGET AND THE QUERY AS WELL, DECISIONS ARE SENT WITHOUT A BODY
+			hash := sha256.New()
+			hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+			hash.Write(body)
+			requestHash := hex.EncodeToString(hash.Sum(nil))
+
+			record, err := service.Begin(r.Context(), identity.EmployeeID, key, requestHash)
+			if err != nil {
+				handlers.WriteError(w, err)
+				return
+			}
+
+			if record.Completed() {
+				for name, values := range record.ResponseHeaders {
+					w.Header()[name] = values
+				}
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(*record.StatusCode)
+				if _, err = w.Write(record.ResponseBody); err != nil {
+					l.Error("Failed to write replayed response", "error", err.Error())
+				}
+				return
+			}
+
+			//HEADERS SET BY THE MIDDLEWARES AROUND BELONG TO THIS REQUEST, ONLY THE ONES THE HANDLER SET ARE STORED
+			before := w.Header().Clone()
+			buf := &responseBuffer{header: w.Header(), status: http.StatusOK}
+			next.ServeHTTP(buf, r)
+
+			if buf.status >= http.StatusInternalServerError {
+				if err = service.Release(r.Context(), *record); err != nil {
+					l.Error("Failed to release idempotency key", "error", err.Error())
+				}
+			} else if err = service.Complete(r.Context(), *record, buf.status, setHeaders(before, buf.header), buf.body.Bytes()); errors.Is(err, service_idempotency.ErrLeaseLost) {
+				//THE RESPONSE STILL GOES TO THE CLIENT, THE KEY BELONGS TO THE REQUEST THAT TOOK IT OVER
+				l.Warn("Idempotent response not stored", "error", err.Error())
+			} else if err != nil {
+				l.Error("Failed to store idempotent response", "error", err.Error())
+			}
+
+			w.WriteHeader(buf.status)
+			if _, err = w.Write(buf.body.Bytes()); err != nil {
+				l.Error("Failed to write response", "error", err.Error())
+			}
+		})
+	}
+}
+
+// setHeaders returns the headers that were added or changed since before
+func setHeaders(before, after http.Header) map[string][]string {
+	headers := make(map[string][]string)
+	for name, values := range after {
+		if !slices.Equal(before[name], values) {
+			headers[name] = values
+		}
+	}
+	return headers
+}
@@ -0,0 +1,99 @@
+package middlewares
+
+import (
+	"avito_intership/internal/handlers"
+	"bytes"
+	"errors"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *responseBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *responseBuffer) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// OpenAPI rejects requests that do not match the specification with 400, routes missing from it are passed through.
+// Authentication is left to Auth. With validateResponses set the response is buffered and checked as well,
+// a mismatch is logged and the response is still sent unchanged.
+func OpenAPI(router routers.Router, validateResponses bool, l *slog.Logger) func(http.Handler) http.Handler {
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+
+			if err = openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				code := handlers.CodeInvalidParameter
+				var reqErr *openapi3filter.RequestError
+				if errors.As(err, &reqErr) && reqErr.RequestBody != nil {
+					code = handlers.CodeInvalidBody
+				}
+
+				handlers.WriteErrorReason(w, http.StatusBadRequest, code, err.Error())
+				return
+			}
+
+			if !validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buf := &responseBuffer{header: w.Header(), status: http.StatusOK}
+			next.ServeHTTP(buf, r)
+
+			body := buf.body.Bytes()
+			if buf.header.Get("Content-Type") == "" && len(body) > 0 {
+				buf.header.Set("Content-Type", http.DetectContentType(body))
+			}
+
+			if err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 buf.status,
+				Header:                 buf.header,
+				Body:                   io.NopCloser(bytes.NewReader(body)),
+				Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+			}); err != nil {
+				l.Error("Response does not match the OpenAPI specification",
+					slog.String("method", r.Method),
+					slog.String("path", route.Path),
+					slog.Int("status", buf.status),
+					slog.String("error", err.Error()))
+			}
+
+			w.WriteHeader(buf.status)
+			if _, err = w.Write(body); err != nil {
+				l.Error("Failed to write response", "error", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package migrator
+
+import (
+	"avito_intership/migrations"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	migrate_pgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"io/fs"
+	"log/slog"
+	"os"
+)
+
+var (
+	ErrOpenConn         = errors.New("failed to open connection to db")
+	ErrMissingTable     = errors.New("required table does not exist")
+	ErrMissingExtension = errors.New("required extension is not installed")
+	ErrDirty            = errors.New("database schema is dirty, fix the failed migration and force the version")
+	ErrWrongDirection   = errors.New("target version is on the other side of the current one")
+	ErrInternal         = errors.New("internal error")
+)
+
+var (
+	requiredTables     = []string{"employee", "organization", "organization_responsible"}
+	requiredExtensions = []string{"uuid-ossp"}
+)
+
+type Migration struct {
+	Version    uint
+	Identifier string
+	Applied    bool
+}
+
+type State struct {
+	Version    uint
+	Dirty      bool
+	Migrations []Migration
+}
+
+type Migrator struct {
+	db      *sql.DB
+	source  source.Driver
+	migrate *migrate.Migrate
+
+	logger *slog.Logger
+}
+
+// CheckPrerequisites makes sure the objects the migrations depend on, but do not create, already exist.
+func (m *Migrator) CheckPrerequisites(ctx context.Context) error {
+	for _, table := range requiredTables {
+		var exists bool
+		if err := m.db.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists); err != nil {
+			m.logger.Error("Failed to check table existence", "table", table, "error", err.Error())
+			return ErrInternal
+		}
+
+		if !exists {
+			return fmt.Errorf("%w: %s", ErrMissingTable, table)
+		}
+	}
+
+	for _, extension := range requiredExtensions {
+		var exists bool
+		if err := m.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pg_extension WHERE extname = $1)", extension).Scan(&exists); err != nil {
+			m.logger.Error("Failed to check extension existence", "extension", extension, "error", err.Error())
+			return ErrInternal
+		}
+
+		if !exists {
+			return fmt.Errorf("%w: %s", ErrMissingExtension, extension)
+		}
+	}
+
+	return nil
+}
+
+// Up applies all pending up-migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	if err := m.CheckPrerequisites(ctx); err != nil {
+		return err
+	}
+
+	if err := m.migrate.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return m.wrapErr(err)
+	}
+
+	return nil
+}
+
+// UpTo applies pending migrations up to and including version. A version below the current one is rejected, not reverted to.
+func (m *Migrator) UpTo(ctx context.Context, version uint) error {
+	if err := m.CheckPrerequisites(ctx); err != nil {
+		return err
+	}
+
+	current, err := m.version()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case version < current:
+		return fmt.Errorf("%w: up to %d from %d", ErrWrongDirection, version, current)
+	case version == current:
+		return nil
+	}
+
+	if err = m.migrate.Migrate(version); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return m.wrapErr(err)
+	}
+
+	return nil
+}
+
+// DownTo reverts every migration above version, version 0 reverts all of them. A version above the current one is rejected.
+func (m *Migrator) DownTo(ctx context.Context, version uint) error {
+	if err := m.CheckPrerequisites(ctx); err != nil {
+		return err
+	}
+
+	current, err := m.version()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case version > current:
+		return fmt.Errorf("%w: down to %d from %d", ErrWrongDirection, version, current)
+	case version == current:
+		return nil
+	case version == 0:
+		err = m.migrate.Down()
+	default:
+		err = m.migrate.Migrate(version)
+	}
+
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return m.wrapErr(err)
+	}
+
+	return nil
+}
+
+// Steps applies n migrations, reverting them when n is negative.
+func (m *Migrator) Steps(ctx context.Context, n int) error {
+	if err := m.CheckPrerequisites(ctx); err != nil {
+		return err
+	}
+
+	if err := m.migrate.Steps(n); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return m.wrapErr(err)
+	}
+
+	return nil
+}
+
+// State returns the current schema version along with every embedded migration.
+func (m *Migrator) State() (State, error) {
+	state := State{}
+
+	version, dirty, err := m.migrate.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		m.logger.Error("Failed to get schema version", "error", err.Error())
+		return State{}, ErrInternal
+	}
+	state.Version, state.Dirty = version, dirty
+
+	current, err := m.source.First()
+	for err == nil {
+		body, identifier, readErr := m.source.ReadUp(current)
+		if readErr != nil {
+			m.logger.Error("Failed to read migration", "version", current, "error", readErr.Error())
+			return State{}, ErrInternal
+		}
+		_ = body.Close()
+
+		state.Migrations = append(state.Migrations, Migration{
+			Version:    current,
+			Identifier: identifier,
+			Applied:    current <= state.Version,
+		})
+
+		current, err = m.source.Next(current)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, fs.ErrNotExist) {
+		m.logger.Error("Failed to list migrations", "error", err.Error())
+		return State{}, ErrInternal
+	}
+
+	return state, nil
+}
+
+// version returns the applied schema version, 0 when nothing is applied yet
+func (m *Migrator) version() (uint, error) {
+	version, dirty, err := m.migrate.Version()
+	switch {
+	case errors.Is(err, migrate.ErrNilVersion):
+		return 0, nil
+	case err != nil:
+		m.logger.Error("Failed to get schema version", "error", err.Error())
+		return 0, ErrInternal
+	case dirty:
+		return 0, fmt.Errorf("%w (version %d)", ErrDirty, version)
+	}
+
+	return version, nil
+}
+
+func (m *Migrator) wrapErr(err error) error {
+	var dirtyErr migrate.ErrDirty
+	if errors.As(err, &dirtyErr) {
+		return fmt.Errorf("%w (version %d)", ErrDirty, dirtyErr.Version)
+	}
+
+	m.logger.Error("Failed to apply migrations", "error", err.Error())
+	return fmt.Errorf("%w: %s", ErrInternal, err.Error())
+}
+
+func (m *Migrator) Close() {
+	if srcErr, dbErr := m.migrate.Close(); srcErr != nil || dbErr != nil {
+		m.logger.Error("Failed to close migrator", "source_error", srcErr, "db_error", dbErr)
+	}
+}
+
+func New(connStr string, logger *slog.Logger) (*Migrator, error) {
+	db, err := sql.Open("pgx", connStr)
+	if err != nil {
+		logger.Error("Failed to open connection to db", "error", err.Error())
+		return nil, ErrOpenConn
+	}
+
+	if err = db.Ping(); err != nil {
+		logger.Error("Failed to ping db", "error", err.Error())
+		return nil, ErrOpenConn
+	}
+
+	driver, err := migrate_pgx.WithInstance(db, &migrate_pgx.Config{})
+	if err != nil {
+		logger.Error("Failed to create migration driver", "error", err.Error())
+		return nil, ErrInternal
+	}
+
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		logger.Error("Failed to read embedded migrations", "error", err.Error())
+		return nil, ErrInternal
+	}
+
+	mg, err := migrate.NewWithInstance("iofs", src, "pgx5", driver)
+	if err != nil {
+		logger.Error("Failed to create migrator", "error", err.Error())
+		return nil, ErrInternal
+	}
+
+	m := &Migrator{
+		db:      db,
+		source:  src,
+		migrate: mg,
+		logger:  logger,
+	}
+
+	return m, nil
+}
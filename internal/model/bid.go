@@ -7,9 +7,22 @@ type Bid struct {
 	Name        *string
 	Description *string
 	Status      *string
-	TenderID    *string
-	AuthorType  *string
-	AuthorID    *string
+	TenderID    *string `sql:"tender_id"`
+	AuthorType  *string `sql:"author_type"`
+	AuthorID    *string `sql:"author_id"`
 	Version     *int
-	CreatedAt   *time.Time
+	CreatedAt   *time.Time `sql:"-"`
+	ChangedBy   *string    `sql:"changed_by"`
+	ChangeKind  *string    `sql:"change_kind"`
 }
+
+const (
+	BidAuthorUser         = "User"
+	BidAuthorOrganization = "Organization"
+)
+
+const (
+	BidStatusCreated   = "Created"
+	BidStatusPublished = "Published"
+	BidStatusCanceled  = "Canceled"
+)
@@ -0,0 +1,28 @@
+package model
+
+const (
+	DecisionApproved = "Approved"
+	DecisionRejected = "Rejected"
+)
+
+// Decision policy types. The rules behind them are registered in the quorum package.
+const (
+	DecisionPolicyMajority       = "Majority"
+	DecisionPolicyUnanimous      = "Unanimous"
+	DecisionPolicyFixedApprovals = "FixedApprovals"
+	DecisionPolicySingleApprover = "SingleApprover"
+)
+
+// DecisionPolicy tells how the votes of the tender organization settle a bid. RequiredApprovals is used by
+// FixedApprovals and ApproverID by SingleApprover only. With RejectionVeto the first rejection rejects the bid.
+type DecisionPolicy struct {
+	Type              string
+	RequiredApprovals *int
+	ApproverID        *string
+	RejectionVeto     bool
+}
+
+// DefaultDecisionPolicy is the policy of tenders created without one: more than half of the representatives approve, any one rejects.
+func DefaultDecisionPolicy() DecisionPolicy {
+	return DecisionPolicy{Type: DecisionPolicyMajority, RejectionVeto: true}
+}
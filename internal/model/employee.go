@@ -1,8 +1,23 @@
 package model
 
+import "time"
+
+// Employee is a user of the service. A deactivated employee keeps its history but can not sign in.
 type Employee struct {
-	ID        int
-	Username  string
-	FirstName string
-	LastName  string
+	ID            string
+	Username      string
+	FirstName     *string
+	LastName      *string
+	DeactivatedAt *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// EmployeePatch carries the fields of an employee to change, nil fields are left as they are.
+// Password is the plain password, it is hashed before it is stored.
+type EmployeePatch struct {
+	Username  *string
+	FirstName *string
+	LastName  *string
+	Password  *string
 }
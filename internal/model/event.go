@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventTenderPublished = "tender.published"
+	EventTenderClosed    = "tender.closed"
+	EventBidSubmitted    = "bid.submitted"
+	EventBidDecision     = "bid.decision_submitted"
+)
+
+const (
+	AggregateTender = "tender"
+	AggregateBid    = "bid"
+)
+
+// Event is a domain event. It is written to the outbox in the transaction that changed the state it describes
+// and delivered to the sinks afterwards, at least once.
+type Event struct {
+	ID            string
+	Type          string
+	AggregateType string
+	AggregateID   string
+	//OrganizationIDs are the organizations the event is about, their webhook subscriptions receive it
+	OrganizationIDs []string
+	Payload         json.RawMessage
+	CreatedAt       time.Time
+	Attempts        int
+}
+
+// TenderEventPayload is the payload of tender.* events. ChangedBy is empty when the change followed an admin action.
+type TenderEventPayload struct {
+	TenderID       string `json:"tender_id"`
+	OrganizationID string `json:"organization_id"`
+	Status         string `json:"status"`
+	Version        int    `json:"version"`
+	ChangedBy      string `json:"changed_by"`
+}
+
+// BidEventPayload is the payload of bid.submitted. A bid is submitted once it is published to the tender owner.
+type BidEventPayload struct {
+	BidID      string `json:"bid_id"`
+	TenderID   string `json:"tender_id"`
+	AuthorType string `json:"author_type"`
+	AuthorID   string `json:"author_id"`
+	Status     string `json:"status"`
+	Version    int    `json:"version"`
+}
+
+// DecisionEventPayload is the payload of bid.decision_submitted. TenderClosed is set when the vote reached the quorum.
+type DecisionEventPayload struct {
+	BidID        string `json:"bid_id"`
+	TenderID     string `json:"tender_id"`
+	Decision     string `json:"decision"`
+	DecidedBy    string `json:"decided_by"`
+	TenderClosed bool   `json:"tender_closed"`
+}
@@ -2,8 +2,37 @@ package model
 
 import "time"
 
+// Feedback is a review the tender organization left on a bid. It is about the bid author, so reviews on all
+// bids of an author make up its track record. Rating is optional, from 1 to 5.
+// UpdatedAt is set once the reviewer replaced the review, CreatedAt stays the time it was first written.
 type Feedback struct {
-	ID          int
+	ID          string
+	BidID       string
+	TenderID    string
+	AuthorType  string
+	AuthorID    string
+	ReviewerID  string
 	Description string
+	Rating      *int
 	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+const (
+	FeedbackMinRating = 1
+	FeedbackMaxRating = 5
+)
+
+// FeedbackStats sums up the reviews on an author. AverageRating and Ratings count rated reviews only,
+// Ratings is keyed by the rating.
+type FeedbackStats struct {
+	Count         int
+	AverageRating *float64
+	Ratings       map[int]int
+}
+
+// BidAuthor is who a bid is submitted by: a user or an organization.
+type BidAuthor struct {
+	Type string
+	ID   string
 }
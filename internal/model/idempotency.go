@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// IdempotencyRecord keeps the first request sent with an idempotency key and, once it finished, its response.
+// While the request is in progress ExpiresAt is the end of its lease, afterwards the end of the replay window.
+// LeaseToken is set for the request that reserved the key only, it stores the response with it.
+type IdempotencyRecord struct {
+	EmployeeID      string
+	Key             string
+	RequestHash     string
+	LeaseToken      string
+	StatusCode      *int
+	ResponseHeaders map[string][]string
+	ResponseBody    []byte
+	ExpiresAt       time.Time
+}
+
+// Completed reports whether the response of the first request has been stored.
+func (r IdempotencyRecord) Completed() bool {
+	return r.StatusCode != nil
+}
@@ -1,8 +1,12 @@
 package model
 
+import "time"
+
 type Organization struct {
-	ID          int
+	ID          string
 	Name        string
-	Description string
-	Type        string
+	Description *string
+	Type        *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// Cursor points at the last row of a page in the (created_at, id) ordering.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Page describes the requested slice of a list. When After is set the list is
+// read with keyset pagination and Offset is ignored.
+type Page struct {
+	Limit     int
+	Offset    int
+	After     *Cursor
+	WithTotal bool
+}
+
+// PageInfo is returned alongside a page. NextCursor is nil on the last page,
+// Total is nil unless it was requested.
+type PageInfo struct {
+	NextCursor *Cursor
+	Total      *int
+}
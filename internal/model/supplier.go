@@ -0,0 +1,14 @@
+package model
+
+// SupplierProfile is the track record of a bid author. Bids are counted once submitted, drafts never reached
+// a tender owner. Won bids closed their tender by quorum, approvals and rejections are the votes cast on the bids.
+type SupplierProfile struct {
+	Author        BidAuthor
+	Name          string
+	BidsSubmitted int
+	BidsWon       int
+	Approvals     int
+	Rejections    int
+	Reviews       FeedbackStats
+	RecentReviews []Feedback
+}
@@ -6,10 +6,44 @@ type Tender struct {
 	ID              *string
 	Name            *string
 	Description     *string
-	ServiceType     *string
+	ServiceType     *string `sql:"service_type"`
 	Status          *string
-	OrganizationID  *string
-	CreatorUsername *string
+	OrganizationID  *string `sql:"organization_id"`
+	CreatorUsername *string `sql:"creator_username"`
 	Version         *int
-	CreatedAt       *time.Time
+	CreatedAt       *time.Time      `sql:"-"`
+	ChangedBy       *string         `sql:"changed_by"`
+	ChangeKind      *string         `sql:"change_kind"`
+	DecisionPolicy  *DecisionPolicy `sql:"-"`
+}
+
+const (
+	TenderStatusCreated   = "Created"
+	TenderStatusPublished = "Published"
+	TenderStatusClosed    = "Closed"
+)
+
+// Closed reports whether the tender has been closed. Closed tenders stay readable but accept no bids.
+func (t Tender) Closed() bool {
+	return t.Status != nil && *t.Status == TenderStatusClosed
+}
+
+const (
+	TenderSortNewest = "newest"
+	TenderSortName   = "name"
+)
+
+// TenderFilter narrows the tender list. Zero values disable the corresponding filter.
+// Results are ranked by relevance when Query is set and Sort is empty.
+type TenderFilter struct {
+	Query          string
+	ServiceTypes   []string
+	Statuses       []string
+	OrganizationID string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	Sort           string
+
+	// ViewerOrganizationIDs are the organizations of the caller. Drafts are listed for them only.
+	ViewerOrganizationIDs []string
 }
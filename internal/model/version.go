@@ -0,0 +1,69 @@
+package model
+
+import "time"
+
+// Change kinds recorded with every tender and bid version.
+const (
+	ChangeKindCreate       = "Create"
+	ChangeKindEdit         = "Edit"
+	ChangeKindStatusChange = "StatusChange"
+	ChangeKindRollback     = "Rollback"
+)
+
+// TenderVersion is a tender as it was at the given version. Tender.ChangedBy and
+// Tender.ChangeKind describe the change that produced the version.
+type TenderVersion struct {
+	Tender    Tender
+	ChangedAt time.Time
+}
+
+// BidVersion is a bid as it was at the given version. Bid.ChangedBy and
+// Bid.ChangeKind describe the change that produced the version.
+type BidVersion struct {
+	Bid       Bid
+	ChangedAt time.Time
+}
+
+// Fields compared in a version diff. The handlers name them the way the resource names them in JSON.
+const (
+	FieldName        = "Name"
+	FieldDescription = "Description"
+	FieldServiceType = "ServiceType"
+	FieldStatus      = "Status"
+	FieldAuthorType  = "AuthorType"
+	FieldAuthorID    = "AuthorID"
+
+	FieldDecisionPolicyType = "DecisionPolicy.Type"
+	FieldRequiredApprovals  = "DecisionPolicy.RequiredApprovals"
+	FieldApproverID         = "DecisionPolicy.ApproverID"
+	FieldRejectionVeto      = "DecisionPolicy.RejectionVeto"
+)
+
+type FieldChange struct {
+	Field string
+	From  *string
+	To    *string
+}
+
+// VersionDiff lists the fields that differ between two versions. ChangedAt, ChangedBy
+// and ChangeKind describe the change that produced the To version.
+type VersionDiff struct {
+	From       int
+	To         int
+	ChangedAt  time.Time
+	ChangedBy  *string
+	ChangeKind *string
+	Changes    []FieldChange
+}
+
+// Compare records the field in Changes when its value differs between the versions.
+func (d *VersionDiff) Compare(field string, from, to *string) {
+	switch {
+	case from == nil && to == nil:
+		return
+	case from != nil && to != nil && *from == *to:
+		return
+	}
+
+	d.Changes = append(d.Changes, FieldChange{Field: field, From: from, To: to})
+}
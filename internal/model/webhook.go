@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// WebhookSubscription is an endpoint registered by an organization. It receives the events about the organization
+// tenders and the bids it authored. An empty EventTypes list subscribes to every event type.
+// Secret is only filled in when the subscription is created or the secret is rotated.
+type WebhookSubscription struct {
+	ID             string
+	OrganizationID string
+	URL            string
+	Secret         string
+	EventTypes     []string
+	Active         bool
+	FailureCount   int
+	DisabledAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookSubscriptionPatch carries the fields of a subscription to change, nil fields are left as they are.
+type WebhookSubscriptionPatch struct {
+	URL        *string
+	EventTypes *[]string
+	Active     *bool
+}
+
+const (
+	WebhookDeliveryPending   = "Pending"
+	WebhookDeliveryDelivered = "Delivered"
+	WebhookDeliveryFailed    = "Failed"
+)
+
+// WebhookDelivery is a single event sent to a subscription. It stays Pending while attempts are left.
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Status         string
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// WebhookDispatch is a due delivery together with everything needed to send it.
+type WebhookDispatch struct {
+	Delivery WebhookDelivery
+	URL      string
+	Secret   string
+	Event    Event
+}
+
+// WebhookEventTypes lists the events a subscription may filter on.
+var WebhookEventTypes = []string{EventTenderPublished, EventTenderClosed, EventBidSubmitted, EventBidDecision}
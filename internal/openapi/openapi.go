@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"net/http"
+)
+
+const uuidPattern = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+
+//go:embed openapi.json
+var spec []byte
+
+// Load parses the embedded specification and checks that it is a valid OpenAPI 3 document.
+func Load(ctx context.Context) (*openapi3.T, error) {
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(uuidPattern))
+	//KEEP VALIDATION ERRORS SHORT, THEY ARE SENT TO THE CLIENT AS IS
+	openapi3.SchemaErrorDetailsDisabled = true
+
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = doc.Validate(ctx); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// Router matches incoming requests to the operations of the document.
+func Router(doc *openapi3.T) (routers.Router, error) {
+	return gorillamux.NewRouter(doc)
+}
+
+// Handler serves the embedded specification as is.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(spec)
+	})
+}
@@ -0,0 +1,91 @@
+package outbox
+
+import (
+	"avito_intership/internal/metrics"
+	"avito_intership/internal/model"
+	service_webhook "avito_intership/internal/service/webhook"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// DeliveryWorker polls the queued webhook deliveries and posts each one to the endpoint of its subscription.
+// Every delivery is attempted once per claim, retries are scheduled by the webhook service.
+type DeliveryWorker struct {
+	service service_webhook.Service
+	client  *http.Client
+
+	interval  time.Duration
+	batchSize int
+
+	logger *slog.Logger
+}
+
+// Run delivers until ctx is cancelled.
+func (w *DeliveryWorker) Run(ctx context.Context) error {
+	w.logger.Info("Starting webhook delivery worker", "interval", w.interval.String())
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		//A FULL BATCH MEANS MORE DELIVERIES ARE DUE, THEY ARE TAKEN WITHOUT WAITING FOR THE NEXT TICK
+		delivered, err := w.DispatchOnce(ctx)
+		if err != nil {
+			w.logger.Error("Failed to send webhook deliveries", "error", err.Error())
+		}
+		if err == nil && delivered == w.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping webhook delivery worker")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce sends a single batch and returns the number of deliveries claimed.
+func (w *DeliveryWorker) DispatchOnce(ctx context.Context) (int, error) {
+	dispatches, err := w.service.Claim(ctx, w.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, dispatch := range dispatches {
+		if ctx.Err() != nil {
+			//THE LEASE EXPIRES AND THE REST OF THE BATCH IS CLAIMED AGAIN
+			return len(dispatches), nil
+		}
+
+		if err = w.send(ctx, dispatch); err != nil {
+			return len(dispatches), err
+		}
+	}
+
+	return len(dispatches), nil
+}
+
+func (w *DeliveryWorker) send(ctx context.Context, dispatch model.WebhookDispatch) error {
+	statusCode, postErr := Post(ctx, w.client, dispatch.URL, dispatch.Secret, dispatch.Event)
+	if postErr != nil {
+		metrics.EventsDelivered.WithLabelValues("subscription", "failure").Inc()
+		return w.service.Failed(ctx, dispatch, statusCode, postErr, Retryable(statusCode))
+	}
+
+	metrics.EventsDelivered.WithLabelValues("subscription", "success").Inc()
+	return w.service.Delivered(ctx, dispatch, statusCode)
+}
+
+func NewDeliveryWorker(service service_webhook.Service, client *http.Client, interval time.Duration, batchSize int, logger *slog.Logger) *DeliveryWorker {
+	return &DeliveryWorker{
+		service:   service,
+		client:    client,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
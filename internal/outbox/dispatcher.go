@@ -0,0 +1,102 @@
+package outbox
+
+import (
+	"avito_intership/internal/metrics"
+	"avito_intership/internal/model"
+	service_outbox "avito_intership/internal/service/outbox"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Dispatcher polls the outbox and hands every due event to all sinks. An event is marked dispatched once
+// every sink accepted it, otherwise it is retried later as a whole.
+type Dispatcher struct {
+	service service_outbox.Service
+	sinks   []Sink
+
+	interval  time.Duration
+	batchSize int
+
+	logger *slog.Logger
+}
+
+// Run dispatches until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	d.logger.Info("Starting outbox dispatcher", "interval", d.interval.String(), "sinks", len(d.sinks))
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		//A FULL BATCH MEANS MORE EVENTS ARE DUE, THEY ARE TAKEN WITHOUT WAITING FOR THE NEXT TICK
+		dispatched, err := d.DispatchOnce(ctx)
+		if err != nil {
+			d.logger.Error("Failed to dispatch outbox events", "error", err.Error())
+		}
+		if err == nil && dispatched == d.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Stopping outbox dispatcher")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce delivers a single batch and returns the number of events taken from the outbox.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	events, err := d.service.Claim(ctx, d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if ctx.Err() != nil {
+			//THE LEASE EXPIRES AND THE REST OF THE BATCH IS CLAIMED AGAIN
+			return len(events), nil
+		}
+
+		if deliverErr := d.deliver(ctx, event); deliverErr != nil {
+			if err = d.service.Failed(ctx, event, deliverErr); err != nil {
+				return len(events), err
+			}
+			continue
+		}
+
+		if err = d.service.Delivered(ctx, event); err != nil {
+			return len(events), err
+		}
+	}
+
+	return len(events), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, event model.Event) error {
+	var errs []error
+	for _, sink := range d.sinks {
+		if err := sink.Deliver(ctx, event); err != nil {
+			metrics.EventsDelivered.WithLabelValues(sink.Name(), "failure").Inc()
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		metrics.EventsDelivered.WithLabelValues(sink.Name(), "success").Inc()
+	}
+
+	return errors.Join(errs...)
+}
+
+func NewDispatcher(service service_outbox.Service, sinks []Sink, interval time.Duration, batchSize int, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		service:   service,
+		sinks:     sinks,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
@@ -0,0 +1,265 @@
+package outbox_test
+
+import (
+	"avito_intership/internal/model"
+	"avito_intership/internal/outbox"
+	service_outbox_impl "avito_intership/internal/service/outbox/implementation"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	webhookSecret = "secret"
+	maxAttempts   = 5
+	retryBackoff  = time.Minute
+	lease         = time.Hour
+)
+
+// row is an outbox_event row, NextAttemptAt doubles as the lease the same way it does in Postgres
+type row struct {
+	event         model.Event
+	nextAttemptAt time.Time
+	dispatched    bool
+	lastError     string
+}
+
+// repository keeps the outbox in memory and follows the semantics of the postgres repository
+type repository struct {
+	mu   sync.Mutex
+	now  time.Time
+	rows []*row
+}
+
+func (r *repository) Add(_ context.Context, event model.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event.ID = "event-" + strconv.Itoa(len(r.rows))
+	event.CreatedAt = r.now
+	r.rows = append(r.rows, &row{event: event, nextAttemptAt: r.now})
+	return nil
+}
+
+func (r *repository) Claim(_ context.Context, limit int, maxAttempts int, lease time.Duration) ([]model.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := make([]model.Event, 0)
+	for _, row := range r.rows {
+		if len(events) == limit {
+			break
+		}
+		if row.dispatched || row.event.Attempts >= maxAttempts || row.nextAttemptAt.After(r.now) {
+			continue
+		}
+		row.nextAttemptAt = r.now.Add(lease)
+		events = append(events, row.event)
+	}
+	return events, nil
+}
+
+func (r *repository) MarkDispatched(_ context.Context, eventID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	row := r.row(eventID)
+	row.dispatched = true
+	row.event.Attempts++
+	row.lastError = ""
+	return nil
+}
+
+func (r *repository) MarkFailed(_ context.Context, eventID string, retryIn time.Duration, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	row := r.row(eventID)
+	row.event.Attempts++
+	row.lastError = lastError
+	row.nextAttemptAt = r.now.Add(retryIn)
+	return nil
+}
+
+func (r *repository) row(eventID string) *row {
+	for _, row := range r.rows {
+		if row.event.ID == eventID {
+			return row
+		}
+	}
+	panic("no event " + eventID)
+}
+
+// endpoint is the local stand-in for a webhook receiver. It answers with status and counts the events it got
+type endpoint struct {
+	server   *httptest.Server
+	status   atomic.Int32
+	mu       sync.Mutex
+	received map[string]int
+}
+
+func newEndpoint(t *testing.T, status int) *endpoint {
+	e := &endpoint{received: make(map[string]int)}
+	e.status.Store(int32(status))
+
+	e.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(outbox.SignatureTimestampHeader), 10, 64)
+		if r.Header.Get(outbox.SignatureHeader) != outbox.Sign(webhookSecret, timestamp, body) {
+			t.Errorf("event %s arrived with a wrong signature", r.Header.Get(outbox.EventIDHeader))
+		}
+
+		e.mu.Lock()
+		e.received[r.Header.Get(outbox.EventIDHeader)]++
+		e.mu.Unlock()
+
+		w.WriteHeader(int(e.status.Load()))
+	}))
+	t.Cleanup(e.server.Close)
+
+	return e
+}
+
+func (e *endpoint) count(eventID string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.received[eventID]
+}
+
+func setup(t *testing.T, status int, events int) (*repository, *endpoint, *outbox.Dispatcher) {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := &repository{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	service := service_outbox_impl.New(repo, maxAttempts, retryBackoff, lease, logger)
+
+	for i := 0; i < events; i++ {
+		if err := service.Publish(context.Background(), model.EventTenderPublished, model.AggregateTender, "tender", nil, map[string]int{"n": i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e := newEndpoint(t, status)
+	sink := outbox.NewWebhookSink(e.server.URL, webhookSecret, e.server.Client(), 0, time.Millisecond)
+	dispatcher := outbox.NewDispatcher(service, []outbox.Sink{sink}, time.Second, 10, logger)
+
+	return repo, e, dispatcher
+}
+
+func TestDispatcherDelivers(t *testing.T) {
+	repo, e, dispatcher := setup(t, http.StatusOK, 2)
+
+	dispatched, err := dispatcher.DispatchOnce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dispatched != 2 {
+		t.Fatalf("expected 2 events taken, got %d", dispatched)
+	}
+
+	for _, row := range repo.rows {
+		if !row.dispatched {
+			t.Errorf("event %s is not marked dispatched", row.event.ID)
+		}
+		if got := e.count(row.event.ID); got != 1 {
+			t.Errorf("event %s delivered %d times", row.event.ID, got)
+		}
+	}
+
+	//DISPATCHED EVENTS ARE NOT TAKEN AGAIN
+	if dispatched, err = dispatcher.DispatchOnce(context.Background()); err != nil || dispatched != 0 {
+		t.Errorf("expected nothing left, got %d events, error %v", dispatched, err)
+	}
+}
+
+func TestDispatcherReschedulesFailures(t *testing.T) {
+	repo, e, dispatcher := setup(t, http.StatusServiceUnavailable, 1)
+	row := repo.rows[0]
+	start := repo.now
+
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if row.dispatched {
+		t.Fatal("a failed event is marked dispatched")
+	}
+	if row.event.Attempts != 1 || row.lastError == "" {
+		t.Errorf("expected one failed attempt with its error, got %d attempts, error %q", row.event.Attempts, row.lastError)
+	}
+	if want := start.Add(retryBackoff); !row.nextAttemptAt.Equal(want) {
+		t.Errorf("expected the next attempt at %s, got %s", want, row.nextAttemptAt)
+	}
+
+	//NOT DUE BEFORE THE BACKOFF HAS PASSED
+	if dispatched, _ := dispatcher.DispatchOnce(context.Background()); dispatched != 0 {
+		t.Errorf("event retried before its backoff, %d taken", dispatched)
+	}
+
+	//THE BACKOFF DOUBLES AFTER THE SECOND FAILURE
+	repo.now = repo.now.Add(retryBackoff)
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := repo.now.Add(2 * retryBackoff); row.event.Attempts != 2 || !row.nextAttemptAt.Equal(want) {
+		t.Errorf("expected 2 attempts and the next one at %s, got %d and %s", want, row.event.Attempts, row.nextAttemptAt)
+	}
+
+	//A RECOVERED ENDPOINT GETS THE EVENT ON THE NEXT DUE ATTEMPT
+	e.status.Store(http.StatusOK)
+	repo.now = repo.now.Add(2 * retryBackoff)
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !row.dispatched || e.count(row.event.ID) != 3 {
+		t.Errorf("expected the event dispatched on the third attempt, dispatched %v after %d attempts", row.dispatched, e.count(row.event.ID))
+	}
+}
+
+func TestDispatcherSkipsLeasedEvents(t *testing.T) {
+	repo, e, dispatcher := setup(t, http.StatusOK, 3)
+
+	//ANOTHER DISPATCHER HOLDS THE LEASE ON THE FIRST EVENT
+	leased, err := repo.Claim(context.Background(), 1, maxAttempts, lease)
+	if err != nil || len(leased) != 1 {
+		t.Fatalf("failed to lease an event: %v", err)
+	}
+
+	//CONCURRENT DISPATCHERS SHARE THE REST WITHOUT DELIVERING ANY EVENT TWICE
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := e.count(leased[0].ID); got != 0 {
+		t.Errorf("leased event %s delivered %d times", leased[0].ID, got)
+	}
+	for _, row := range repo.rows[1:] {
+		if got := e.count(row.event.ID); got != 1 {
+			t.Errorf("event %s delivered %d times", row.event.ID, got)
+		}
+	}
+
+	//THE EVENT IS TAKEN AGAIN ONCE THE LEASE EXPIRES WITHOUT AN OUTCOME
+	repo.now = repo.now.Add(lease)
+	if _, err = dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := e.count(leased[0].ID); got != 1 {
+		t.Errorf("event %s delivered %d times after its lease expired", leased[0].ID, got)
+	}
+}
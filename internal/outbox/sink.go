@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"avito_intership/internal/model"
+	"context"
+	"log/slog"
+)
+
+// Sink receives the events taken from the outbox. Delivery is at least once, so a sink may see an event again
+// when another sink failed on it.
+type Sink interface {
+	Name() string
+	Deliver(ctx context.Context, event model.Event) error
+}
+
+// LogSink writes every event to the log.
+type LogSink struct {
+	logger *slog.Logger
+}
+
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+func (s *LogSink) Deliver(_ context.Context, event model.Event) error {
+	s.logger.Info("Domain event",
+		slog.String("event_id", event.ID),
+		slog.String("event_type", event.Type),
+		slog.String("aggregate_type", event.AggregateType),
+		slog.String("aggregate_id", event.AggregateID),
+		slog.String("payload", string(event.Payload)))
+	return nil
+}
+
+func NewLogSink(logger *slog.Logger) *LogSink {
+	return &LogSink{
+		logger: logger,
+	}
+}
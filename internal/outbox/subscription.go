@@ -0,0 +1,27 @@
+package outbox
+
+import (
+	"avito_intership/internal/model"
+	service_webhook "avito_intership/internal/service/webhook"
+	"context"
+)
+
+// SubscriptionSink queues every event for the webhook subscriptions of the organizations it is about.
+// The deliveries themselves are sent by DeliveryWorker, so a slow endpoint does not hold the outbox back.
+type SubscriptionSink struct {
+	service service_webhook.Service
+}
+
+func (s *SubscriptionSink) Name() string {
+	return "subscriptions"
+}
+
+func (s *SubscriptionSink) Deliver(ctx context.Context, event model.Event) error {
+	return s.service.Enqueue(ctx, event)
+}
+
+func NewSubscriptionSink(service service_webhook.Service) *SubscriptionSink {
+	return &SubscriptionSink{
+		service: service,
+	}
+}
@@ -0,0 +1,130 @@
+package outbox
+
+import (
+	"avito_intership/internal/model"
+	"avito_intership/pkg/backoff"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	EventIDHeader            = "X-Event-ID"
+	EventTypeHeader          = "X-Event-Type"
+	SignatureHeader          = "X-Signature"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+)
+
+// WebhookBody is the JSON posted to webhook endpoints.
+type WebhookBody struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// Sign returns the signature sent in X-Signature: the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret.
+// Receivers recompute it to check the sender and reject stale timestamps to stop replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Post sends the signed event to the endpoint. It returns the response status, 0 when no response arrived.
+func Post(ctx context.Context, client *http.Client, url, secret string, event model.Event) (int, error) {
+	body, err := json.Marshal(WebhookBody{
+		ID:            event.ID,
+		Type:          event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		CreatedAt:     event.CreatedAt,
+		Data:          event.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	//THE SIGNATURE IS RECOMPUTED ON EVERY ATTEMPT, SO A RETRY IS NOT MISTAKEN FOR A REPLAY
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, event.ID)
+	req.Header.Set(EventTypeHeader, event.Type)
+	req.Header.Set(SignatureTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook answered %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Retryable reports whether a failed post may succeed later: no response at all, 408, 429 or 5xx.
+func Retryable(statusCode int) bool {
+	return statusCode == 0 || statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests ||
+		statusCode >= http.StatusInternalServerError
+}
+
+// WebhookSink posts events to a single endpoint. Retryable failures are retried with the same capped exponential backoff
+// as outbox and webhook deliveries, any other non-2xx status fails the delivery at once.
+type WebhookSink struct {
+	url     string
+	secret  string
+	client  *http.Client
+	retries int
+	backoff time.Duration
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Deliver(ctx context.Context, event model.Event) error {
+	for attempt := 1; ; attempt++ {
+		statusCode, err := Post(ctx, s.client, s.url, s.secret, event)
+		if err == nil || !Retryable(statusCode) || attempt > s.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff.Delay(s.backoff, attempt)):
+		}
+	}
+}
+
+func NewWebhookSink(url, secret string, client *http.Client, retries int, backoff time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:     url,
+		secret:  secret,
+		client:  client,
+		retries: retries,
+		backoff: backoff,
+	}
+}
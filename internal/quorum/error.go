@@ -0,0 +1,8 @@
+package quorum
+
+import "errors"
+
+var (
+	ErrUnknownPolicy = errors.New("unknown decision policy")
+	ErrInvalidPolicy = errors.New("invalid decision policy parameters")
+)
@@ -0,0 +1,107 @@
+package quorum
+
+import (
+	"avito_intership/internal/model"
+	"context"
+	"fmt"
+)
+
+type Outcome int
+
+const (
+	Pending Outcome = iota
+	Accepted
+	Rejected
+)
+
+// Tally is the vote on a bid. Approvals and rejections are counted for current representatives only.
+type Tally struct {
+	Representatives int
+	Approvals       int
+	Rejections      int
+	//ApproverDecision is the decision of the designated approver, empty until it is submitted
+	ApproverDecision string
+}
+
+// Rule settles a bid once its tally allows it and reports Pending until then.
+type Rule interface {
+	Outcome(tally Tally) Outcome
+}
+
+// RuleFunc lets a plain function be used as a Rule.
+type RuleFunc func(tally Tally) Outcome
+
+func (f RuleFunc) Outcome(tally Tally) Outcome {
+	return f(tally)
+}
+
+// Settler settles the bids pending on open tenders of the organizations once their representatives or a decision policy
+// have changed, as the votes already cast may make up the new quorum of a bid.
+type Settler interface {
+	Settle(ctx context.Context, organizationIDs []string) error
+}
+
+// SettlerFunc lets a plain function be used as a Settler.
+type SettlerFunc func(ctx context.Context, organizationIDs []string) error
+
+func (f SettlerFunc) Settle(ctx context.Context, organizationIDs []string) error {
+	return f(ctx, organizationIDs)
+}
+
+// Factory builds the rule of a policy type. It returns ErrInvalidPolicy when the policy lacks the parameters the type needs.
+type Factory func(policy model.DecisionPolicy) (Rule, error)
+
+// Registry maps decision policy types to the factories of their rules.
+// Types are registered at startup, the registry is read-only once it is shared.
+type Registry struct {
+	factories map[string]Factory
+}
+
+// Register adds the policy type or replaces the factory registered for it.
+func (r *Registry) Register(policyType string, factory Factory) {
+	r.factories[policyType] = factory
+}
+
+// Rule builds the rule of the policy. With the rejection veto the first rejection rejects the bid, whatever the type says.
+func (r *Registry) Rule(policy model.DecisionPolicy) (Rule, error) {
+	factory, ok := r.factories[policy.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, policy.Type)
+	}
+
+	rule, err := factory(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.RejectionVeto {
+		return rule, nil
+	}
+
+	return RuleFunc(func(tally Tally) Outcome {
+		if tally.Rejections > 0 {
+			return Rejected
+		}
+		return rule.Outcome(tally)
+	}), nil
+}
+
+// Validate checks that the policy type is registered and its parameters are complete.
+func (r *Registry) Validate(policy model.DecisionPolicy) error {
+	_, err := r.Rule(policy)
+	return err
+}
+
+// New returns a registry with the built-in policy types.
+func New() *Registry {
+	r := &Registry{
+		factories: make(map[string]Factory),
+	}
+
+	r.Register(model.DecisionPolicyMajority, majority)
+	r.Register(model.DecisionPolicyUnanimous, unanimous)
+	r.Register(model.DecisionPolicyFixedApprovals, fixedApprovals)
+	r.Register(model.DecisionPolicySingleApprover, singleApprover)
+
+	return r
+}
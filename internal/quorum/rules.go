@@ -0,0 +1,60 @@
+package quorum
+
+import "avito_intership/internal/model"
+
+// approvalsOf accepts the bid once required approvals are cast. It rejects the bid once so many representatives
+// rejected it that the rest can no longer make up the required approvals, so a bid is never left pending for good.
+func approvalsOf(tally Tally, required int) Outcome {
+	switch {
+	case tally.Approvals > 0 && tally.Approvals >= required:
+		return Accepted
+	case tally.Rejections > 0 && tally.Rejections > tally.Representatives-required:
+		return Rejected
+	default:
+		return Pending
+	}
+}
+
+// majority accepts the bid once more than half of the representatives approve it
+func majority(model.DecisionPolicy) (Rule, error) {
+	return RuleFunc(func(tally Tally) Outcome {
+		return approvalsOf(tally, tally.Representatives/2+1)
+	}), nil
+}
+
+// unanimous accepts the bid once every representative approves it
+func unanimous(model.DecisionPolicy) (Rule, error) {
+	return RuleFunc(func(tally Tally) Outcome {
+		return approvalsOf(tally, tally.Representatives)
+	}), nil
+}
+
+// fixedApprovals accepts the bid once the required number of representatives approve it
+func fixedApprovals(policy model.DecisionPolicy) (Rule, error) {
+	if policy.RequiredApprovals == nil || *policy.RequiredApprovals < 1 {
+		return nil, ErrInvalidPolicy
+	}
+	required := *policy.RequiredApprovals
+
+	return RuleFunc(func(tally Tally) Outcome {
+		return approvalsOf(tally, required)
+	}), nil
+}
+
+// singleApprover leaves the bid to the designated approver, the decisions of other representatives count for the veto only
+func singleApprover(policy model.DecisionPolicy) (Rule, error) {
+	if policy.ApproverID == nil || *policy.ApproverID == "" {
+		return nil, ErrInvalidPolicy
+	}
+
+	return RuleFunc(func(tally Tally) Outcome {
+		switch tally.ApproverDecision {
+		case model.DecisionApproved:
+			return Accepted
+		case model.DecisionRejected:
+			return Rejected
+		default:
+			return Pending
+		}
+	}), nil
+}
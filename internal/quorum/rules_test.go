@@ -0,0 +1,68 @@
+package quorum_test
+
+import (
+	"avito_intership/internal/model"
+	"avito_intership/internal/quorum"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	two := 2
+	approverID := "approver"
+
+	tests := []struct {
+		policy model.DecisionPolicy
+		tally  quorum.Tally
+		want   quorum.Outcome
+	}{
+		{model.DecisionPolicy{Type: model.DecisionPolicyMajority}, quorum.Tally{Representatives: 4, Approvals: 2}, quorum.Pending},
+		{model.DecisionPolicy{Type: model.DecisionPolicyMajority}, quorum.Tally{Representatives: 4, Approvals: 3}, quorum.Accepted},
+		{model.DecisionPolicy{Type: model.DecisionPolicyMajority}, quorum.Tally{Representatives: 3, Approvals: 2}, quorum.Accepted},
+		{model.DecisionPolicy{Type: model.DecisionPolicyMajority}, quorum.Tally{Representatives: 4, Approvals: 2, Rejections: 1}, quorum.Pending},
+		{model.DecisionPolicy{Type: model.DecisionPolicyMajority}, quorum.Tally{Representatives: 4, Rejections: 2}, quorum.Rejected},
+		{model.DecisionPolicy{Type: model.DecisionPolicyMajority, RejectionVeto: true}, quorum.Tally{Representatives: 4, Approvals: 2, Rejections: 1}, quorum.Rejected},
+
+		{model.DecisionPolicy{Type: model.DecisionPolicyUnanimous}, quorum.Tally{Representatives: 3, Approvals: 2}, quorum.Pending},
+		{model.DecisionPolicy{Type: model.DecisionPolicyUnanimous}, quorum.Tally{Representatives: 3, Approvals: 3}, quorum.Accepted},
+		{model.DecisionPolicy{Type: model.DecisionPolicyUnanimous}, quorum.Tally{Representatives: 3, Approvals: 2, Rejections: 1}, quorum.Rejected},
+
+		{model.DecisionPolicy{Type: model.DecisionPolicyFixedApprovals, RequiredApprovals: &two}, quorum.Tally{Representatives: 5, Approvals: 1}, quorum.Pending},
+		{model.DecisionPolicy{Type: model.DecisionPolicyFixedApprovals, RequiredApprovals: &two}, quorum.Tally{Representatives: 5, Approvals: 2}, quorum.Accepted},
+		{model.DecisionPolicy{Type: model.DecisionPolicyFixedApprovals, RequiredApprovals: &two}, quorum.Tally{Representatives: 5, Rejections: 3}, quorum.Pending},
+		{model.DecisionPolicy{Type: model.DecisionPolicyFixedApprovals, RequiredApprovals: &two}, quorum.Tally{Representatives: 5, Approvals: 1, Rejections: 4}, quorum.Rejected},
+
+		{model.DecisionPolicy{Type: model.DecisionPolicySingleApprover, ApproverID: &approverID}, quorum.Tally{Representatives: 3, Approvals: 2}, quorum.Pending},
+		{model.DecisionPolicy{Type: model.DecisionPolicySingleApprover, ApproverID: &approverID}, quorum.Tally{Representatives: 3, Approvals: 1, ApproverDecision: model.DecisionApproved}, quorum.Accepted},
+		{model.DecisionPolicy{Type: model.DecisionPolicySingleApprover, ApproverID: &approverID}, quorum.Tally{Representatives: 3, Rejections: 1, ApproverDecision: model.DecisionRejected}, quorum.Rejected},
+	}
+
+	registry := quorum.New()
+	for _, tt := range tests {
+		rule, err := registry.Rule(tt.policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := rule.Outcome(tt.tally); got != tt.want {
+			t.Errorf("%s veto %v %+v: got %d, want %d", tt.policy.Type, tt.policy.RejectionVeto, tt.tally, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	zero := 0
+
+	tests := []model.DecisionPolicy{
+		{Type: "Plurality"},
+		{Type: model.DecisionPolicyFixedApprovals},
+		{Type: model.DecisionPolicyFixedApprovals, RequiredApprovals: &zero},
+		{Type: model.DecisionPolicySingleApprover},
+	}
+
+	registry := quorum.New()
+	for _, policy := range tests {
+		if err := registry.Validate(policy); err == nil {
+			t.Errorf("policy %+v accepted", policy)
+		}
+	}
+}
@@ -14,5 +14,17 @@ func ToBidFromRepository(bid repository_bid_model.Bid) model.Bid {
 		AuthorID:   &bid.AuthorID,
 		Version:    &bid.Version,
 		CreatedAt:  &bid.CreatedAt,
+		ChangedBy:  bid.ChangedBy,
+		ChangeKind: bid.ChangeKind,
+	}
+}
+
+func ToBidVersionFromRepository(version repository_bid_model.BidVersion) model.BidVersion {
+	bid := ToBidFromRepository(version.Bid)
+	bid.Description = &version.Description
+
+	return model.BidVersion{
+		Bid:       bid,
+		ChangedAt: version.ChangedAt,
 	}
 }
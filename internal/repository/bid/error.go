@@ -14,4 +14,5 @@ var (
 
 	ErrNoBids               = errors.New("no bid")
 	ErrNoSuggestionToUpdate = errors.New("no suggestion to update")
+	ErrVersionConflict      = errors.New("version conflict")
 )
@@ -10,4 +10,6 @@ type Bid struct {
 	AuthorID   string
 	Version    int
 	CreatedAt  time.Time
+	ChangedBy  *string
+	ChangeKind *string
 }
@@ -0,0 +1,9 @@
+package repository_bid_model
+
+import "time"
+
+type BidVersion struct {
+	Bid
+	Description string
+	ChangedAt   time.Time
+}
@@ -7,6 +7,7 @@ import (
 	repository_bid_converter "avito_intership/internal/repository/bid/converter"
 	repository_bid_model "avito_intership/internal/repository/bid/model"
 	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
 	"avito_intership/pkg/sql_patch"
 	"context"
 	"database/sql"
@@ -14,13 +15,12 @@ import (
 	"fmt"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"log/slog"
 	"strings"
 )
 
 type rep struct {
-	pool   *pgxpool.Pool
+	db     *postgres.DB
 	logger *slog.Logger
 }
 
@@ -34,14 +34,16 @@ func (r *rep) Create(ctx context.Context, bid model.Bid) (model.Bid, error) {
 
 	repositoryBid := repository_bid_model.Bid{}
 
-	stmt := `INSERT INTO bid (name, description, tender_id, author_type, author_id) VALUES ($1,$2,$3,$4,$5) 
-RETURNING id, name, status, author_type, author_id, version, created_at`
-	row := r.pool.QueryRow(ctx, stmt,
+	stmt := `INSERT INTO bid (name, description, tender_id, author_type, author_id, changed_by, change_kind) VALUES ($1,$2,$3,$4,$5,$6,$7)
+RETURNING id, name, status, author_type, author_id, version, created_at, changed_by, change_kind`
+	row := r.db.QueryRow(ctx, stmt,
 		*bid.Name,
 		*bid.Description,
 		*bid.TenderID,
 		*bid.AuthorType,
-		*bid.AuthorID)
+		*bid.AuthorID,
+		bid.ChangedBy,
+		model.ChangeKindCreate)
 
 	if err := row.Scan(&repositoryBid.ID,
 		&repositoryBid.Name,
@@ -49,7 +51,9 @@ RETURNING id, name, status, author_type, author_id, version, created_at`
 		&repositoryBid.AuthorType,
 		&repositoryBid.AuthorID,
 		&repositoryBid.Version,
-		&repositoryBid.CreatedAt); err != nil {
+		&repositoryBid.CreatedAt,
+		&repositoryBid.ChangedBy,
+		&repositoryBid.ChangeKind); err != nil {
 
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -74,62 +78,86 @@ RETURNING id, name, status, author_type, author_id, version, created_at`
 	return repository_bid_converter.ToBidFromRepository(repositoryBid), nil
 }
 
-func (r *rep) BidsByAuthorID(ctx context.Context, userID, organizationID string, limit int, offset int) ([]model.Bid, error) {
+func (r *rep) BidsByAuthorID(ctx context.Context, userID string, organizationIDs []string, page model.Page) ([]model.Bid, model.PageInfo, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := `SELECT id, name, status, author_type, author_id, version, created_at FROM bid
-                                                                  WHERE author_id IN ($1, $2) ORDER BY name LIMIT $3 OFFSET $4`
-
-	rows, err := r.pool.Query(ctx, stmt, userID, organizationID, limit, offset)
-	if err != nil {
-		l.Error("Failed to get list of user bid", "error", err.Error())
-		return nil, repository_bid.ErrInternal
+	args := make([]interface{}, 0)
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
 	}
-	defer rows.Close()
-
-	bids := make([]model.Bid, 0, limit)
 
-	for rows.Next() {
-		bid := repository_bid_model.Bid{}
-		if err = rows.Scan(&bid.ID,
-			&bid.Name,
-			&bid.Status,
-			&bid.AuthorType,
-			&bid.AuthorID,
-			&bid.Version,
-			&bid.CreatedAt); err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, repository_bid.ErrNoBids
-			}
+	condition := fmt.Sprintf("(author_id = %s OR author_id = ANY(%s))", placeholder(userID), placeholder(organizationIDs))
 
-			l.Error("Failed to get list of user bid", "error", err.Error())
-			return nil, repository_bid.ErrInternal
+	pageInfo := model.PageInfo{}
+	if page.WithTotal {
+		total, err := repository.Total(ctx, r.db, "bid", repository.Where(condition), args)
+		if err != nil {
+			l.Error("Failed to count user bids", "error", err.Error())
+			return nil, model.PageInfo{}, repository_bid.ErrInternal
 		}
-
-		bids = append(bids, repository_bid_converter.ToBidFromRepository(bid))
+		pageInfo.Total = total
 	}
 
-	if err = rows.Err(); err != nil {
+	stmt := fmt.Sprintf("SELECT id, name, status, author_type, author_id, version, created_at, changed_by, change_kind FROM bid %s ORDER BY created_at DESC, id DESC %s",
+		repository.Where(condition, repository.KeysetCondition(page, placeholder)), repository.LimitOffset(page, placeholder))
+
+	bids, err := r.bids(ctx, stmt, args...)
+	if err != nil {
 		l.Error("Failed to get list of user bid", "error", err.Error())
-		return nil, repository_bid.ErrInternal
+		return nil, model.PageInfo{}, repository_bid.ErrInternal
 	}
 
-	return bids, nil
+	bids, pageInfo.NextCursor = repository.NextPage(bids, page, bidCursor)
+
+	return bids, pageInfo, nil
 }
 
-func (r *rep) BidsByTenderID(ctx context.Context, tenderID string, limit int, offset int) (bids []model.Bid, err error) {
+func (r *rep) BidsByTenderID(ctx context.Context, tenderID string, viewerID string, viewerOrganizationIDs []string, page model.Page) ([]model.Bid, model.PageInfo, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := `SELECT id, name, status, author_type, author_id, version, created_at FROM bid
-                                                                  WHERE tender_id = $1 LIMIT $2 OFFSET $3`
-	rows, err := r.pool.Query(ctx, stmt, tenderID, limit, offset)
+	args := make([]interface{}, 0)
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	//DRAFTS ARE LISTED FOR THEIR AUTHOR ONLY
+	condition := fmt.Sprintf("tender_id = %s AND (status <> 'Created' OR author_id = %s OR author_id = ANY(%s))",
+		placeholder(tenderID), placeholder(viewerID), placeholder(viewerOrganizationIDs))
+
+	pageInfo := model.PageInfo{}
+	if page.WithTotal {
+		total, err := repository.Total(ctx, r.db, "bid", repository.Where(condition), args)
+		if err != nil {
+			l.Error("Failed to count bids by tender_id", "error", err.Error())
+			return nil, model.PageInfo{}, repository_bid.ErrInternal
+		}
+		pageInfo.Total = total
+	}
+
+	stmt := fmt.Sprintf("SELECT id, name, status, author_type, author_id, version, created_at, changed_by, change_kind FROM bid %s ORDER BY created_at DESC, id DESC %s",
+		repository.Where(condition, repository.KeysetCondition(page, placeholder)), repository.LimitOffset(page, placeholder))
+
+	bids, err := r.bids(ctx, stmt, args...)
 	if err != nil {
 		l.Error("Failed to get bid by tender_id", "error", err.Error())
-		return nil, repository_bid.ErrInternal
+		return nil, model.PageInfo{}, repository_bid.ErrInternal
+	}
+
+	bids, pageInfo.NextCursor = repository.NextPage(bids, page, bidCursor)
+
+	return bids, pageInfo, nil
+}
+
+func (r *rep) bids(ctx context.Context, stmt string, args ...interface{}) ([]model.Bid, error) {
+	rows, err := r.db.Query(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
 	}
 	defer rows.Close()
 
-	bids = make([]model.Bid, 0, limit)
+	bids := make([]model.Bid, 0)
 
 	for rows.Next() {
 		bid := repository_bid_model.Bid{}
@@ -139,31 +167,31 @@ func (r *rep) BidsByTenderID(ctx context.Context, tenderID string, limit int, of
 			&bid.AuthorType,
 			&bid.AuthorID,
 			&bid.Version,
-			&bid.CreatedAt); err != nil {
-
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, repository_bid.ErrNoBids
-			}
-
-			l.Error("Failed to get bid by tender_id", "error", err.Error())
-			return nil, repository_bid.ErrInternal
+			&bid.CreatedAt,
+			&bid.ChangedBy,
+			&bid.ChangeKind); err != nil {
+			return nil, err
 		}
 
 		bids = append(bids, repository_bid_converter.ToBidFromRepository(bid))
 	}
 
 	if err = rows.Err(); err != nil {
-		l.Error("Failed to get bid by tender_id", "error", err.Error())
-		return nil, repository_bid.ErrInternal
+		return nil, err
 	}
 
 	return bids, nil
 }
 
+// bidCursor keys the page on created_at, which is NOT NULL since migration 19
+func bidCursor(bid model.Bid) model.Cursor {
+	return model.Cursor{CreatedAt: *bid.CreatedAt, ID: *bid.ID}
+}
+
 func (r *rep) GetStatus(ctx context.Context, bidID string) (status string, tenderID string, authorID string, err error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 	stmt := "SELECT status, tender_id, author_id  FROM bid WHERE id = $1"
-	if err = r.pool.QueryRow(ctx, stmt, bidID).Scan(&status, &tenderID, &authorID); err != nil {
+	if err = r.db.QueryRow(ctx, stmt, bidID).Scan(&status, &tenderID, &authorID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", "", "", repository_bid.ErrNoBids
 		}
@@ -174,19 +202,21 @@ func (r *rep) GetStatus(ctx context.Context, bidID string) (status string, tende
 	return status, tenderID, authorID, nil
 }
 
-func (r *rep) ChangeStatus(ctx context.Context, bidID string, status string) (bid model.Bid, err error) {
+func (r *rep) ChangeStatus(ctx context.Context, bidID string, status string, changedBy string) (bid model.Bid, err error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
 	repositoryBid := repository_bid_model.Bid{}
 
-	stmt := "UPDATE bid SET status = $1 WHERE id = $2 RETURNING id, name, status, author_type, author_id, version, created_at"
-	if err = r.pool.QueryRow(ctx, stmt, status, bidID).Scan(&repositoryBid.ID,
+	stmt := "UPDATE bid SET status = $1, changed_by = $3, change_kind = 'StatusChange' WHERE id = $2 RETURNING id, name, status, author_type, author_id, version, created_at, changed_by, change_kind"
+	if err = r.db.QueryRow(ctx, stmt, status, bidID, changedBy).Scan(&repositoryBid.ID,
 		&repositoryBid.Name,
 		&repositoryBid.Status,
 		&repositoryBid.AuthorType,
 		&repositoryBid.AuthorID,
 		&repositoryBid.Version,
-		&repositoryBid.CreatedAt); err != nil {
+		&repositoryBid.CreatedAt,
+		&repositoryBid.ChangedBy,
+		&repositoryBid.ChangeKind); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.Bid{}, repository_bid.ErrNoBids
 		}
@@ -206,7 +236,7 @@ func (r *rep) ChangeStatus(ctx context.Context, bidID string, status string) (bi
 	return repository_bid_converter.ToBidFromRepository(repositoryBid), nil
 }
 
-func (r *rep) Edit(ctx context.Context, bidID string, bid model.Bid) (updatedBid model.Bid, err error) {
+func (r *rep) Edit(ctx context.Context, bidID string, bid model.Bid, expectedVersion *int) (updatedBid model.Bid, err error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
 	sqlPatch := sql_patch.SQLPatches(bid)
@@ -215,20 +245,32 @@ func (r *rep) Edit(ctx context.Context, bidID string, bid model.Bid) (updatedBid
 		return model.Bid{}, repository_bid.ErrNoSuggestionToUpdate
 	}
 
-	stmt := fmt.Sprintf(`UPDATE bid SET %s WHERE id = $%d
-                   RETURNING id, name, status, author_type, author_id, version, created_at`, strings.Join(sqlPatch.Fields, ", "), len(sqlPatch.Args)+1)
+	args := append(sqlPatch.Args, bidID)
+	condition := fmt.Sprintf("id = $%d", len(args))
+	if expectedVersion != nil {
+		args = append(args, *expectedVersion)
+		condition += fmt.Sprintf(" AND version = $%d", len(args))
+	}
+
+	stmt := fmt.Sprintf(`UPDATE bid SET %s WHERE %s
+                   RETURNING id, name, status, author_type, author_id, version, created_at, changed_by, change_kind`, strings.Join(sqlPatch.Fields, ", "), condition)
 
 	repositoryBid := repository_bid_model.Bid{}
 
-	row := r.pool.QueryRow(ctx, stmt, append(sqlPatch.Args, bidID)...)
+	row := r.db.QueryRow(ctx, stmt, args...)
 	if err = row.Scan(&repositoryBid.ID,
 		&repositoryBid.Name,
 		&repositoryBid.Status,
 		&repositoryBid.AuthorType,
 		&repositoryBid.AuthorID,
 		&repositoryBid.Version,
-		&repositoryBid.CreatedAt); err != nil {
+		&repositoryBid.CreatedAt,
+		&repositoryBid.ChangedBy,
+		&repositoryBid.ChangeKind); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			if expectedVersion != nil {
+				return model.Bid{}, r.versionMismatch(ctx, bidID)
+			}
 			return model.Bid{}, repository_bid.ErrNoBids
 		}
 
@@ -239,44 +281,50 @@ func (r *rep) Edit(ctx context.Context, bidID string, bid model.Bid) (updatedBid
 	return repository_bid_converter.ToBidFromRepository(repositoryBid), nil
 }
 
-func (r *rep) BidTenderID(ctx context.Context, bidID string) (tenderID string, err error) {
+// versionMismatch tells a missing bid from one that has moved past the expected version.
+func (r *rep) versionMismatch(ctx context.Context, bidID string) error {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := "SELECT tender_id FROM bid WHERE id = $1"
-
-	if err = r.pool.QueryRow(ctx, stmt, bidID).Scan(&tenderID); err != nil {
-		l.Error("Failed to get organization id", "error", err.Error())
-		return "", repository_bid.ErrInternal
+	var exists bool
+	if err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM bid WHERE id = $1)", bidID).Scan(&exists); err != nil {
+		l.Error("Failed to check bid existence", "error", err.Error())
+		return repository_bid.ErrInternal
 	}
 
-	return tenderID, nil
+	if !exists {
+		return repository_bid.ErrNoBids
+	}
+	return repository_bid.ErrVersionConflict
 }
 
-func (r *rep) BidAuthorID(ctx context.Context, bidID string) (authorID string, err error) {
+func (r *rep) BidTenderID(ctx context.Context, bidID string) (tenderID string, err error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := "SELECT author_id FROM bid WHERE id = $1"
+	stmt := "SELECT tender_id FROM bid WHERE id = $1"
 
-	if err = r.pool.QueryRow(ctx, stmt, bidID).Scan(&authorID); err != nil {
+	if err = r.db.QueryRow(ctx, stmt, bidID).Scan(&tenderID); err != nil {
 		l.Error("Failed to get organization id", "error", err.Error())
 		return "", repository_bid.ErrInternal
 	}
 
-	return authorID, nil
+	return tenderID, nil
 }
 
 func (r *rep) BidByID(ctx context.Context, bidID string) (bid model.Bid, err error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := "SELECT id, name, status, author_type, author_id, version, created_at FROM bid WHERE id = $1"
+	stmt := "SELECT id, name, status, tender_id, author_type, author_id, version, created_at, changed_by, change_kind FROM bid WHERE id = $1"
 
-	if err = r.pool.QueryRow(ctx, stmt, bidID).Scan(&bid.ID,
+	if err = r.db.QueryRow(ctx, stmt, bidID).Scan(&bid.ID,
 		&bid.Name,
 		&bid.Status,
+		&bid.TenderID,
 		&bid.AuthorType,
 		&bid.AuthorID,
 		&bid.Version,
-		&bid.CreatedAt); err != nil {
+		&bid.CreatedAt,
+		&bid.ChangedBy,
+		&bid.ChangeKind); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.Bid{}, repository_bid.ErrNoBids
 		}
@@ -287,7 +335,90 @@ func (r *rep) BidByID(ctx context.Context, bidID string) (bid model.Bid, err err
 	return bid, nil
 }
 
-func (r *rep) RollbackVersion(ctx context.Context, bidID string, version int) (model.Bid, error) {
+func (r *rep) AuthorHasBid(ctx context.Context, tenderID string, authors []model.BidAuthor) (bool, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	args := []interface{}{tenderID}
+	conditions := make([]string, 0, len(authors))
+	for _, author := range authors {
+		args = append(args, author.Type, author.ID)
+		conditions = append(conditions, fmt.Sprintf("(author_type = $%d AND author_id = $%d)", len(args)-1, len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return false, nil
+	}
+
+	stmt := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM bid WHERE tender_id = $1 AND (%s))", strings.Join(conditions, " OR "))
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, stmt, args...).Scan(&exists); err != nil {
+		l.Error("Failed to check author bid", "error", err.Error())
+		return false, repository_bid.ErrInternal
+	}
+
+	return exists, nil
+}
+
+func (r *rep) AuthorBidOnOrganizations(ctx context.Context, authors []model.BidAuthor, organizationIDs []string) (bool, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	args := []interface{}{organizationIDs}
+	conditions := make([]string, 0, len(authors))
+	for _, author := range authors {
+		args = append(args, author.Type, author.ID)
+		conditions = append(conditions, fmt.Sprintf("(b.author_type = $%d AND b.author_id = $%d)", len(args)-1, len(args)))
+	}
+
+	if len(conditions) == 0 || len(organizationIDs) == 0 {
+		return false, nil
+	}
+
+	stmt := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM bid b JOIN tender t ON t.id = b.tender_id
+WHERE t.organization_id = ANY($1) AND b.status <> 'Created' AND (%s))`, strings.Join(conditions, " OR "))
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, stmt, args...).Scan(&exists); err != nil {
+		l.Error("Failed to check author bid on organizations", "error", err.Error())
+		return false, repository_bid.ErrInternal
+	}
+
+	return exists, nil
+}
+
+func (r *rep) OpenBids(ctx context.Context, organizationIDs []string) (map[string][]string, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := `SELECT b.tender_id, b.id FROM bid b JOIN tender t ON t.id = b.tender_id
+WHERE t.organization_id = ANY($1) AND t.status = 'Published' AND b.status = 'Published'
+ORDER BY b.tender_id, b.created_at, b.id`
+
+	rows, err := r.db.Query(ctx, stmt, organizationIDs)
+	if err != nil {
+		l.Error("Failed to get open bids", "error", err.Error())
+		return nil, repository_bid.ErrInternal
+	}
+	defer rows.Close()
+
+	bidIDsByTender := make(map[string][]string)
+	for rows.Next() {
+		var tenderID, bidID string
+		if err = rows.Scan(&tenderID, &bidID); err != nil {
+			l.Error("Failed to scan open bid", "error", err.Error())
+			return nil, repository_bid.ErrInternal
+		}
+		bidIDsByTender[tenderID] = append(bidIDsByTender[tenderID], bidID)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Error("Failed to get open bids", "error", err.Error())
+		return nil, repository_bid.ErrInternal
+	}
+
+	return bidIDsByTender, nil
+}
+
+func (r *rep) RollbackVersion(ctx context.Context, bidID string, version int, changedBy string) (model.Bid, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
 	stmt := `UPDATE bid
@@ -297,22 +428,25 @@ func (r *rep) RollbackVersion(ctx context.Context, bidID string, version int) (m
 		tender_id = bh.tender_id,
 		author_type = bh.author_type,
 		author_id = bh.author_id,
-		version = bh.version,
-		created_at = bh.created_at
+		created_at = bh.created_at,
+		changed_by = $3,
+		change_kind = 'Rollback'
 	FROM bid_history bh
 	WHERE bid.id = bh.id AND bh.id = $1 AND bh.version = $2
-	RETURNING bh.id, bh.name, bh.status, bh.author_type, bh.author_id, bh.version, bh.created_at`
+	RETURNING bid.id, bid.name, bid.status, bid.author_type, bid.author_id, bid.version, bid.created_at, bid.changed_by, bid.change_kind`
 
 	repositoryBid := repository_bid_model.Bid{}
 
-	row := r.pool.QueryRow(ctx, stmt, bidID, version)
+	row := r.db.QueryRow(ctx, stmt, bidID, version, changedBy)
 	if err := row.Scan(&repositoryBid.ID,
 		&repositoryBid.Name,
 		&repositoryBid.Status,
 		&repositoryBid.AuthorType,
 		&repositoryBid.AuthorID,
 		&repositoryBid.Version,
-		&repositoryBid.CreatedAt); err != nil {
+		&repositoryBid.CreatedAt,
+		&repositoryBid.ChangedBy,
+		&repositoryBid.ChangeKind); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.Bid{}, repository_bid.ErrNoBids
 		}
@@ -322,26 +456,94 @@ func (r *rep) RollbackVersion(ctx context.Context, bidID string, version int) (m
 	return repository_bid_converter.ToBidFromRepository(repositoryBid), nil
 }
 
-func (r *rep) CloseConn() {
-	r.pool.Close()
-}
+// bidVersionsStmt lists the archived versions together with the current one. Versions written before
+// changed_at was recorded fall back to the archive time of their predecessor.
+const bidVersionsStmt = `SELECT id, name, description, status, author_type, author_id, version, created_at, changed_by, change_kind,
+		COALESCE(changed_at, LAG(archived_at) OVER (ORDER BY version), created_at) AS changed_at
+	FROM (
+		SELECT id, name, description, status, author_type, author_id, version, created_at, changed_by, change_kind, changed_at, updated_at AS archived_at
+		FROM bid_history WHERE id = $1
+		UNION ALL
+		SELECT id, name, description, status, author_type, author_id, version, created_at, changed_by, change_kind, changed_at, NULL
+		FROM bid WHERE id = $1
+	) versions`
+
+func (r *rep) Versions(ctx context.Context, bidID string) ([]model.BidVersion, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
 
-func New(ctx context.Context, connStr string, logger *slog.Logger) (repository_bid.Repository, error) {
-	pool, err := pgxpool.New(ctx, connStr)
+	rows, err := r.db.Query(ctx, bidVersionsStmt+" ORDER BY version", bidID)
 	if err != nil {
-		logger.Error("Failed to open connection to db", "error", err.Error())
-		return nil, repository.ErrOpenConn
+		l.Error("Failed to get bid versions", "error", err.Error())
+		return nil, repository_bid.ErrInternal
 	}
+	defer rows.Close()
 
-	if err = pool.Ping(ctx); err != nil {
-		logger.Error("Failed to ping db", "error", err.Error())
-		return nil, repository.ErrPingDB
+	versions := make([]model.BidVersion, 0)
+
+	for rows.Next() {
+		version := repository_bid_model.BidVersion{}
+		if err = rows.Scan(&version.ID,
+			&version.Name,
+			&version.Description,
+			&version.Status,
+			&version.AuthorType,
+			&version.AuthorID,
+			&version.Version,
+			&version.CreatedAt,
+			&version.ChangedBy,
+			&version.ChangeKind,
+			&version.ChangedAt); err != nil {
+			l.Error("Failed to get bid versions", "error", err.Error())
+			return nil, repository_bid.ErrInternal
+		}
+		versions = append(versions, repository_bid_converter.ToBidVersionFromRepository(version))
 	}
 
+	if err = rows.Err(); err != nil {
+		l.Error("Failed to get bid versions", "error", err.Error())
+		return nil, repository_bid.ErrInternal
+	}
+
+	if len(versions) == 0 {
+		return nil, repository_bid.ErrNoBids
+	}
+
+	return versions, nil
+}
+
+func (r *rep) Version(ctx context.Context, bidID string, version int) (model.BidVersion, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf("SELECT id, name, description, status, author_type, author_id, version, created_at, changed_by, change_kind, changed_at FROM (%s) v WHERE version = $2", bidVersionsStmt)
+
+	repositoryVersion := repository_bid_model.BidVersion{}
+	if err := r.db.QueryRow(ctx, stmt, bidID, version).Scan(&repositoryVersion.ID,
+		&repositoryVersion.Name,
+		&repositoryVersion.Description,
+		&repositoryVersion.Status,
+		&repositoryVersion.AuthorType,
+		&repositoryVersion.AuthorID,
+		&repositoryVersion.Version,
+		&repositoryVersion.CreatedAt,
+		&repositoryVersion.ChangedBy,
+		&repositoryVersion.ChangeKind,
+		&repositoryVersion.ChangedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.BidVersion{}, repository_bid.ErrNoBids
+		}
+
+		l.Error("Failed to get bid version", "error", err.Error())
+		return model.BidVersion{}, repository_bid.ErrInternal
+	}
+
+	return repository_bid_converter.ToBidVersionFromRepository(repositoryVersion), nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_bid.Repository {
 	r := &rep{
-		pool:   pool,
+		db:     db,
 		logger: logger,
 	}
 
-	return r, nil
+	return r
 }
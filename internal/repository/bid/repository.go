@@ -7,14 +7,25 @@ import (
 
 type Repository interface {
 	Create(ctx context.Context, bid model.Bid) (model.Bid, error)
-	BidsByTenderID(ctx context.Context, tenderID string, limit int, offset int) (bids []model.Bid, err error)
-	BidsByAuthorID(ctx context.Context, userID, organizationID string, limit int, offset int) ([]model.Bid, error)
+	//BidsByTenderID returns the bids on the tender, drafts are included only when the viewer or one of its organizations authored them
+	BidsByTenderID(ctx context.Context, tenderID string, viewerID string, viewerOrganizationIDs []string, page model.Page) ([]model.Bid, model.PageInfo, error)
+	//BidsByAuthorID returns the bids of the user and of the organizations it represents
+	BidsByAuthorID(ctx context.Context, userID string, organizationIDs []string, page model.Page) ([]model.Bid, model.PageInfo, error)
 	GetStatus(ctx context.Context, bidID string) (status string, tenderID string, authorID string, err error)
-	ChangeStatus(ctx context.Context, bidID string, status string) (bid model.Bid, err error)
-	Edit(ctx context.Context, bidID string, bid model.Bid) (updatedBid model.Bid, err error)
+	ChangeStatus(ctx context.Context, bidID string, status string, changedBy string) (bid model.Bid, err error)
+	//Edit patches the bid. With expectedVersion set the patch applies only while the bid is still at that version
+	Edit(ctx context.Context, bidID string, bid model.Bid, expectedVersion *int) (updatedBid model.Bid, err error)
 	BidTenderID(ctx context.Context, bidID string) (tenderID string, err error)
-	BidAuthorID(ctx context.Context, bidID string) (authorID string, err error)
 	BidByID(ctx context.Context, bidID string) (model.Bid, error)
-	RollbackVersion(ctx context.Context, bidID string, version int) (model.Bid, error)
-	CloseConn()
+	//AuthorHasBid reports whether any of the authors bid on the tender
+	AuthorHasBid(ctx context.Context, tenderID string, authors []model.BidAuthor) (bool, error)
+	//AuthorBidOnOrganizations reports whether any of the authors submitted a bid on a tender of any of the organizations
+	AuthorBidOnOrganizations(ctx context.Context, authors []model.BidAuthor, organizationIDs []string) (bool, error)
+	//OpenBids returns the published bids on published tenders of the organizations by tender, oldest bid first
+	OpenBids(ctx context.Context, organizationIDs []string) (bidIDsByTender map[string][]string, err error)
+	//RollbackVersion restores the archived version as a new version attributed to changedBy
+	RollbackVersion(ctx context.Context, bidID string, version int, changedBy string) (model.Bid, error)
+	//Versions returns every version of the bid, the current one included, oldest first
+	Versions(ctx context.Context, bidID string) ([]model.BidVersion, error)
+	Version(ctx context.Context, bidID string, version int) (model.BidVersion, error)
 }
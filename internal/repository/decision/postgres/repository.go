@@ -1,20 +1,19 @@
 package repository_decision_postgres
 
 import (
-	"avito_intership/internal/repository"
 	repository_decision "avito_intership/internal/repository/decision"
 	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
 	"context"
 	"database/sql"
 	"errors"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"log/slog"
 )
 
 type rep struct {
-	pool   *pgxpool.Pool
+	db     *postgres.DB
 	logger *slog.Logger
 }
 
@@ -23,7 +22,7 @@ func (r *rep) SubmitDecision(ctx context.Context, authorID string, tenderID stri
 
 	stmt := "INSERT INTO decision(tender_author_id, tender_id, bid_id, decision) VALUES($1, $2, $3, $4)"
 
-	_, err := r.pool.Exec(ctx, stmt, authorID, tenderID, bidID, decision)
+	_, err := r.db.Exec(ctx, stmt, authorID, tenderID, bidID, decision)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -45,10 +44,18 @@ func (r *rep) SubmitDecision(ctx context.Context, authorID string, tenderID stri
 func (r *rep) DecisionStats(ctx context.Context, bidID string) (applied int, rejected int, err error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := `SELECT COUNT(*) FILTER (WHERE decision = 'Approved') AS approvals, COUNT(*) FILTER (WHERE decision = 'Rejected') AS rejections FROM decision
-	WHERE bid_id = $1`
-
-	if err = r.pool.QueryRow(ctx, stmt, bidID).Scan(&applied, &rejected); err != nil {
+	//DECISIONS COUNT ONLY WHILE THEIR AUTHOR STILL REPRESENTS THE TENDER ORGANIZATION, THE SAME WAY THE QUORUM IS COUNTED
+	stmt := `SELECT COUNT(*) FILTER (WHERE d.decision = 'Approved') AS approvals,
+			 COUNT(*) FILTER (WHERE d.decision = 'Rejected') AS rejections
+			 FROM decision d
+			 JOIN tender t ON t.id = d.tender_id
+			 WHERE d.bid_id = $1 AND EXISTS (
+				 SELECT 1 FROM organization_responsible r
+				 JOIN employee e ON e.id = r.user_id
+				 WHERE r.organization_id = t.organization_id AND r.user_id = d.tender_author_id AND e.deactivated_at IS NULL
+			 )`
+
+	if err = r.db.QueryRow(ctx, stmt, bidID).Scan(&applied, &rejected); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, 0, repository_decision.ErrNoVotes
 		}
@@ -59,26 +66,53 @@ func (r *rep) DecisionStats(ctx context.Context, bidID string) (applied int, rej
 	return applied, rejected, err
 }
 
-func (r *rep) CloseConn() {
-	r.pool.Close()
+func (r *rep) Decision(ctx context.Context, bidID string, authorID string) (decision string, err error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	//THE DECISION COUNTS ONLY WHILE ITS AUTHOR STILL REPRESENTS THE TENDER ORGANIZATION, THE SAME AS IN DecisionStats
+	stmt := `SELECT d.decision
+			 FROM decision d
+			 JOIN tender t ON t.id = d.tender_id
+			 JOIN organization_responsible r ON r.organization_id = t.organization_id AND r.user_id = d.tender_author_id
+			 JOIN employee e ON e.id = r.user_id
+			 WHERE d.bid_id = $1 AND d.tender_author_id = $2 AND e.deactivated_at IS NULL`
+
+	if err = r.db.QueryRow(ctx, stmt, bidID, authorID).Scan(&decision); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", repository_decision.ErrNoVotes
+		}
+		l.Error("Failed to get decision by author", "error", err.Error())
+		return "", repository_decision.ErrInternal
+	}
+
+	return decision, nil
 }
 
-func New(ctx context.Context, connStr string, logger *slog.Logger) (repository_decision.Repository, error) {
-	pool, err := pgxpool.New(ctx, connStr)
+func (r *rep) Award(ctx context.Context, tenderID string, bidID string) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	//THE AUTHOR IS COPIED, SO THE WIN STAYS WITH WHOEVER SUBMITTED THE BID
+	stmt := `INSERT INTO tender_award (tender_id, bid_id, author_type, author_id)
+			 SELECT tender_id, id, author_type, author_id FROM bid WHERE id = $2 AND tender_id = $1`
+
+	tag, err := r.db.Exec(ctx, stmt, tenderID, bidID)
 	if err != nil {
-		logger.Error("Failed to open connection to db", "error", err.Error())
-		return nil, repository.ErrOpenConn
+		l.Error("Failed to award tender", "error", err.Error())
+		return repository_decision.ErrInternal
 	}
 
-	if err = pool.Ping(ctx); err != nil {
-		logger.Error("Failed to ping db", "error", err.Error())
-		return nil, repository.ErrPingDB
+	if tag.RowsAffected() == 0 {
+		return repository_decision.ErrInvalidForeignKey
 	}
 
+	return nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_decision.Repository {
 	r := &rep{
-		pool:   pool,
+		db:     db,
 		logger: logger,
 	}
 
-	return r, nil
+	return r
 }
@@ -4,6 +4,10 @@ import "context"
 
 type Repository interface {
 	SubmitDecision(ctx context.Context, authorID string, tenderID string, bidID string, decision string) error
+	//DecisionStats counts the decisions of current active representatives only
 	DecisionStats(ctx context.Context, bidID string) (applied int, rejected int, err error)
-	CloseConn()
+	//Decision returns the decision of the author on the bid, ErrNoVotes until a current representative submits one
+	Decision(ctx context.Context, bidID string, authorID string) (decision string, err error)
+	//Award records the bid as the winner of its tender
+	Award(ctx context.Context, tenderID string, bidID string) error
 }
@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrInternal            = errors.New("internal error")
 	ErrNonExistingEmployee = errors.New("non existing employee")
+	ErrUsernameTaken       = errors.New("username is taken")
 )
@@ -1,18 +1,23 @@
 package repository_employee_postgres
 
 import (
-	"avito_intership/internal/repository"
+	"avito_intership/internal/model"
 	repository_employee "avito_intership/internal/repository/employee"
 	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
 	"context"
 	"database/sql"
 	"errors"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"fmt"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"log/slog"
+	"strings"
 )
 
 type rep struct {
-	pool   *pgxpool.Pool
+	db     *postgres.DB
 	logger *slog.Logger
 }
 
@@ -20,7 +25,7 @@ func (r *rep) IDByUsername(ctx context.Context, username string) (userID string,
 	l := logger.EndToEndLogging(ctx, r.logger)
 
 	stmt := "SELECT id FROM employee WHERE username = $1"
-	row := r.pool.QueryRow(ctx, stmt, username)
+	row := r.db.QueryRow(ctx, stmt, username)
 	if err = row.Scan(&userID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", repository_employee.ErrNonExistingEmployee
@@ -33,26 +38,155 @@ func (r *rep) IDByUsername(ctx context.Context, username string) (userID string,
 	return userID, nil
 }
 
-func (r *rep) CloseConn() {
-	r.pool.Close()
+func (r *rep) UsernameByID(ctx context.Context, userID string) (username string, err error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := "SELECT username FROM employee WHERE id = $1 AND deactivated_at IS NULL"
+	if err = r.db.QueryRow(ctx, stmt, userID).Scan(&username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", repository_employee.ErrNonExistingEmployee
+		}
+
+		l.Error("Failed to get username by user_id", "error", err.Error())
+		return "", repository_employee.ErrInternal
+	}
+
+	return username, nil
 }
 
-func New(ctx context.Context, connectionStr string, logger *slog.Logger) (repository_employee.Repository, error) {
-	pool, err := pgxpool.New(ctx, connectionStr)
+func (r *rep) CredentialsByUsername(ctx context.Context, username string) (userID string, passwordHash string, err error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := "SELECT id, COALESCE(password_hash, '') FROM employee WHERE username = $1 AND deactivated_at IS NULL"
+	if err = r.db.QueryRow(ctx, stmt, username).Scan(&userID, &passwordHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", repository_employee.ErrNonExistingEmployee
+		}
+
+		l.Error("Failed to get employee credentials", "error", err.Error())
+		return "", "", repository_employee.ErrInternal
+	}
+
+	return userID, passwordHash, nil
+}
+
+const employeeColumns = "id, username, first_name, last_name, deactivated_at, created_at, updated_at"
+
+func scanEmployee(row pgx.Row) (employee model.Employee, err error) {
+	err = row.Scan(&employee.ID,
+		&employee.Username,
+		&employee.FirstName,
+		&employee.LastName,
+		&employee.DeactivatedAt,
+		&employee.CreatedAt,
+		&employee.UpdatedAt)
+	return employee, err
+}
+
+func (r *rep) Create(ctx context.Context, employee model.Employee, passwordHash *string) (model.Employee, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf(`INSERT INTO employee (username, first_name, last_name, password_hash) VALUES ($1, $2, $3, $4)
+			 RETURNING %s`, employeeColumns)
+
+	created, err := scanEmployee(r.db.QueryRow(ctx, stmt, employee.Username, employee.FirstName, employee.LastName, passwordHash))
 	if err != nil {
-		logger.Error("Failed to create connection to db", "error", err.Error())
-		return nil, repository.ErrOpenConn
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return model.Employee{}, repository_employee.ErrUsernameTaken
+		}
+
+		l.Error("Failed to create employee", "error", err.Error())
+		return model.Employee{}, repository_employee.ErrInternal
 	}
 
-	if err = pool.Ping(ctx); err != nil {
-		logger.Error("Failed to ping db", "error", err.Error())
-		return nil, repository.ErrPingDB
+	return created, nil
+}
+
+func (r *rep) Employee(ctx context.Context, userID string) (model.Employee, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf("SELECT %s FROM employee WHERE id = $1", employeeColumns)
+
+	employee, err := scanEmployee(r.db.QueryRow(ctx, stmt, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Employee{}, repository_employee.ErrNonExistingEmployee
+		}
+
+		l.Error("Failed to get employee", "error", err.Error())
+		return model.Employee{}, repository_employee.ErrInternal
+	}
+
+	return employee, nil
+}
+
+func (r *rep) Update(ctx context.Context, userID string, patch model.EmployeePatch, passwordHash *string) (model.Employee, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	args := []interface{}{userID}
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	fields := []string{"updated_at = CURRENT_TIMESTAMP"}
+	if patch.Username != nil {
+		fields = append(fields, "username = "+placeholder(*patch.Username))
+	}
+	if patch.FirstName != nil {
+		fields = append(fields, "first_name = "+placeholder(*patch.FirstName))
+	}
+	if patch.LastName != nil {
+		fields = append(fields, "last_name = "+placeholder(*patch.LastName))
+	}
+	if passwordHash != nil {
+		fields = append(fields, "password_hash = "+placeholder(*passwordHash))
+	}
+
+	stmt := fmt.Sprintf("UPDATE employee SET %s WHERE id = $1 RETURNING %s", strings.Join(fields, ", "), employeeColumns)
+
+	employee, err := scanEmployee(r.db.QueryRow(ctx, stmt, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return model.Employee{}, repository_employee.ErrNonExistingEmployee
+		case errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation:
+			return model.Employee{}, repository_employee.ErrUsernameTaken
+		}
+
+		l.Error("Failed to update employee", "error", err.Error())
+		return model.Employee{}, repository_employee.ErrInternal
+	}
+
+	return employee, nil
+}
+
+func (r *rep) Deactivate(ctx context.Context, userID string) (model.Employee, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf(`UPDATE employee SET deactivated_at = COALESCE(deactivated_at, CURRENT_TIMESTAMP), updated_at = CURRENT_TIMESTAMP
+			 WHERE id = $1 RETURNING %s`, employeeColumns)
+
+	employee, err := scanEmployee(r.db.QueryRow(ctx, stmt, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Employee{}, repository_employee.ErrNonExistingEmployee
+		}
+
+		l.Error("Failed to deactivate employee", "error", err.Error())
+		return model.Employee{}, repository_employee.ErrInternal
 	}
 
+	return employee, nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_employee.Repository {
 	r := &rep{
-		pool:   pool,
+		db:     db,
 		logger: logger,
 	}
 
-	return r, nil
+	return r
 }
@@ -1,8 +1,19 @@
 package repository_employee
 
-import "context"
+import (
+	"avito_intership/internal/model"
+	"context"
+)
 
 type Repository interface {
 	IDByUsername(ctx context.Context, username string) (userID string, err error)
-	CloseConn()
+	//UsernameByID resolves active employees only
+	UsernameByID(ctx context.Context, userID string) (username string, err error)
+	//CredentialsByUsername resolves active employees only
+	CredentialsByUsername(ctx context.Context, username string) (userID string, passwordHash string, err error)
+	Create(ctx context.Context, employee model.Employee, passwordHash *string) (model.Employee, error)
+	Employee(ctx context.Context, userID string) (model.Employee, error)
+	Update(ctx context.Context, userID string, patch model.EmployeePatch, passwordHash *string) (model.Employee, error)
+	//Deactivate keeps the employee and its history, deactivating it again is a no-op
+	Deactivate(ctx context.Context, userID string) (model.Employee, error)
 }
@@ -8,7 +8,22 @@ import (
 func ToFeedbackFromRepository(feedback repository_feedback_model.Feedback) model.Feedback {
 	return model.Feedback{
 		ID:          feedback.ID,
+		BidID:       value(feedback.BidID),
+		TenderID:    value(feedback.TenderID),
+		AuthorType:  value(feedback.AuthorType),
+		AuthorID:    value(feedback.AuthorID),
+		ReviewerID:  value(feedback.ReviewerID),
 		Description: feedback.Description,
+		Rating:      feedback.Rating,
 		CreatedAt:   feedback.CreatedAt,
+		UpdatedAt:   feedback.UpdatedAt,
 	}
 }
+
+// value reads the references that reviews written before they were recorded lack
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -3,7 +3,14 @@ package repository_feedback_model
 import "time"
 
 type Feedback struct {
-	ID          int
+	ID          string
+	BidID       *string
+	TenderID    *string
+	AuthorType  *string
+	AuthorID    *string
+	ReviewerID  *string
 	Description string
+	Rating      *int
 	CreatedAt   time.Time
+	UpdatedAt   *time.Time
 }
@@ -7,85 +7,187 @@ import (
 	repository_feedback_converter "avito_intership/internal/repository/feedback/converter"
 	repository_feedback_model "avito_intership/internal/repository/feedback/model"
 	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
 	"context"
-	"database/sql"
-	"errors"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"fmt"
+	"github.com/jackc/pgx/v5"
 	"log/slog"
+	"strings"
 )
 
 type rep struct {
-	pool   *pgxpool.Pool
+	db     *postgres.DB
 	logger *slog.Logger
 }
 
-func (r *rep) Feedback(ctx context.Context, userID string, feedback string) error {
+const feedbackColumns = "id, bid_id, tender_id, author_type, author_id, reviewer_id, description, rating, created_at, updated_at"
+
+func scanFeedback(row pgx.Row) (model.Feedback, error) {
+	feedback := repository_feedback_model.Feedback{}
+	if err := row.Scan(&feedback.ID,
+		&feedback.BidID,
+		&feedback.TenderID,
+		&feedback.AuthorType,
+		&feedback.AuthorID,
+		&feedback.ReviewerID,
+		&feedback.Description,
+		&feedback.Rating,
+		&feedback.CreatedAt,
+		&feedback.UpdatedAt); err != nil {
+		return model.Feedback{}, err
+	}
+
+	return repository_feedback_converter.ToFeedbackFromRepository(feedback), nil
+}
+
+// authorsCondition matches reviews on bids of any of the authors
+func authorsCondition(authors []model.BidAuthor, placeholder func(arg interface{}) string) string {
+	conditions := make([]string, 0, len(authors))
+	for _, author := range authors {
+		conditions = append(conditions, fmt.Sprintf("(author_type = %s AND author_id = %s)", placeholder(author.Type), placeholder(author.ID)))
+	}
+
+	if len(conditions) == 0 {
+		return "FALSE"
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+func (r *rep) Feedback(ctx context.Context, feedback model.Feedback) (model.Feedback, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := "INSERT INTO review(description, author_username) VALUES ($1, $2)"
-	if _, err := r.pool.Exec(ctx, stmt, feedback, userID); err != nil {
+	stmt := fmt.Sprintf(`INSERT INTO review (bid_id, tender_id, author_type, author_id, reviewer_id, description, rating)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 ON CONFLICT (bid_id, reviewer_id) DO UPDATE SET description = EXCLUDED.description, rating = EXCLUDED.rating,
+			     updated_at = CURRENT_TIMESTAMP
+			 RETURNING %s`, feedbackColumns)
+
+	created, err := scanFeedback(r.db.QueryRow(ctx, stmt, feedback.BidID, feedback.TenderID, feedback.AuthorType, feedback.AuthorID,
+		feedback.ReviewerID, feedback.Description, feedback.Rating))
+	if err != nil {
 		l.Error("Failed to create feedback", "error", err.Error())
-		return repository_feedback.ErrInternal
+		return model.Feedback{}, repository_feedback.ErrInternal
 	}
-	return nil
+
+	return created, nil
 }
 
-func (r *rep) GetFeedbacks(ctx context.Context, authorUsername string, limit int, offset int) ([]model.Feedback, error) {
+func (r *rep) GetFeedbacks(ctx context.Context, authors []model.BidAuthor, page model.Page) ([]model.Feedback, model.PageInfo, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	feedbacks := make([]model.Feedback, 0, limit)
+	args := make([]interface{}, 0)
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	condition := authorsCondition(authors, placeholder)
+
+	pageInfo := model.PageInfo{}
+	if page.WithTotal {
+		total, err := repository.Total(ctx, r.db, "review", repository.Where(condition), args)
+		if err != nil {
+			l.Error("Failed to count reviews", "error", err.Error())
+			return nil, model.PageInfo{}, repository_feedback.ErrInternal
+		}
+		pageInfo.Total = total
+	}
 
-	stmt := "SELECT id, description, created_at FROM review WHERE author_username = $1 LIMIT $2 OFFSET $3"
+	stmt := fmt.Sprintf("SELECT %s FROM review %s ORDER BY created_at DESC, id DESC %s", feedbackColumns,
+		repository.Where(condition, repository.KeysetCondition(page, placeholder)), repository.LimitOffset(page, placeholder))
 
-	rows, err := r.pool.Query(ctx, stmt, authorUsername, limit, offset)
+	rows, err := r.db.Query(ctx, stmt, args...)
 	if err != nil {
 		l.Error("Failed to get review", "error", err.Error())
-		return nil, repository_feedback.ErrInternal
+		return nil, model.PageInfo{}, repository_feedback.ErrInternal
 	}
 	defer rows.Close()
 
-	for rows.Next() {
-		feedback := repository_feedback_model.Feedback{}
-		if err = rows.Scan(&feedback.ID, &feedback.Description, &feedback.CreatedAt); err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, repository_feedback.ErrNoReviews
-			}
+	feedbacks := make([]model.Feedback, 0, page.Limit+1)
 
+	for rows.Next() {
+		feedback, err := scanFeedback(rows)
+		if err != nil {
 			l.Error("Failed to get review", "error", err.Error())
-			return nil, repository_feedback.ErrInternal
+			return nil, model.PageInfo{}, repository_feedback.ErrInternal
 		}
 
-		feedbacks = append(feedbacks, repository_feedback_converter.ToFeedbackFromRepository(feedback))
+		feedbacks = append(feedbacks, feedback)
 	}
 
 	if err = rows.Err(); err != nil {
 		l.Error("Failed to get review", "error", err.Error())
-		return nil, repository_feedback.ErrInternal
+		return nil, model.PageInfo{}, repository_feedback.ErrInternal
 	}
 
-	return feedbacks, err
-}
+	feedbacks, pageInfo.NextCursor = repository.NextPage(feedbacks, page, func(feedback model.Feedback) model.Cursor {
+		return model.Cursor{CreatedAt: feedback.CreatedAt, ID: feedback.ID}
+	})
 
-func (r *rep) CloseConn() {
-	r.pool.Close()
+	return feedbacks, pageInfo, nil
 }
 
-func New(ctx context.Context, connStr string, logger *slog.Logger) (repository_feedback.Repository, error) {
-	pool, err := pgxpool.New(ctx, connStr)
+func (r *rep) Stats(ctx context.Context, authors []model.BidAuthor) (model.FeedbackStats, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	args := make([]interface{}, 0)
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	//A ROW PER RATING, UNRATED REVIEWS ARE COUNTED UNDER NULL
+	stmt := fmt.Sprintf("SELECT rating, COUNT(*) FROM review %s GROUP BY rating", repository.Where(authorsCondition(authors, placeholder)))
+
+	rows, err := r.db.Query(ctx, stmt, args...)
 	if err != nil {
-		logger.Error("Failed to open connection to db", "error", err.Error())
-		return nil, repository.ErrOpenConn
+		l.Error("Failed to get review stats", "error", err.Error())
+		return model.FeedbackStats{}, repository_feedback.ErrInternal
 	}
+	defer rows.Close()
 
-	if err = pool.Ping(ctx); err != nil {
-		logger.Error("Failed to ping db", "error", err.Error())
-		return nil, repository.ErrPingDB
+	stats := model.FeedbackStats{Ratings: make(map[int]int, model.FeedbackMaxRating)}
+	for rating := model.FeedbackMinRating; rating <= model.FeedbackMaxRating; rating++ {
+		stats.Ratings[rating] = 0
 	}
 
+	var rated, sum int
+	for rows.Next() {
+		var (
+			rating *int
+			count  int
+		)
+		if err = rows.Scan(&rating, &count); err != nil {
+			l.Error("Failed to get review stats", "error", err.Error())
+			return model.FeedbackStats{}, repository_feedback.ErrInternal
+		}
+
+		stats.Count += count
+		if rating != nil {
+			stats.Ratings[*rating] = count
+			rated += count
+			sum += *rating * count
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Error("Failed to get review stats", "error", err.Error())
+		return model.FeedbackStats{}, repository_feedback.ErrInternal
+	}
+
+	if rated > 0 {
+		average := float64(sum) / float64(rated)
+		stats.AverageRating = &average
+	}
+
+	return stats, nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_feedback.Repository {
 	r := &rep{
-		pool:   pool,
+		db:     db,
 		logger: logger,
 	}
 
-	return r, nil
+	return r
 }
@@ -6,7 +6,9 @@ import (
 )
 
 type Repository interface {
-	Feedback(ctx context.Context, userID string, feedback string) error
-	GetFeedbacks(ctx context.Context, authorUsername string, limit int, offset int) ([]model.Feedback, error)
-	CloseConn()
+	//Feedback stores the review, a second review of the same bid by the same reviewer replaces the first one
+	Feedback(ctx context.Context, feedback model.Feedback) (model.Feedback, error)
+	//GetFeedbacks returns the reviews on bids of any of the authors, newest first
+	GetFeedbacks(ctx context.Context, authors []model.BidAuthor, page model.Page) ([]model.Feedback, model.PageInfo, error)
+	Stats(ctx context.Context, authors []model.BidAuthor) (model.FeedbackStats, error)
 }
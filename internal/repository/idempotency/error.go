@@ -0,0 +1,9 @@
+package repository_idempotency
+
+import "errors"
+
+var (
+	ErrInternal  = errors.New("internal error")
+	ErrNoRecord  = errors.New("no idempotency record")
+	ErrLeaseLost = errors.New("idempotency key lease has been lost")
+)
@@ -0,0 +1,117 @@
+package repository_idempotency_postgres
+
+import (
+	"avito_intership/internal/model"
+	repository_idempotency "avito_intership/internal/repository/idempotency"
+	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+type rep struct {
+	db     *postgres.DB
+	logger *slog.Logger
+}
+
+func (r *rep) Reserve(ctx context.Context, employeeID, key, requestHash string, lease time.Duration) (model.IdempotencyRecord, bool, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	//AN EXPIRED RECORD IS TAKEN OVER AS IF THE KEY WAS NEVER USED
+	stmt := `INSERT INTO idempotency_key (employee_id, key, request_hash, lease_token, expires_at)
+			 VALUES ($1, $2, $3, uuid_generate_v4(), CURRENT_TIMESTAMP + make_interval(secs => $4))
+			 ON CONFLICT (employee_id, key) DO UPDATE
+			 SET request_hash = EXCLUDED.request_hash, lease_token = EXCLUDED.lease_token, status_code = NULL, response_headers = NULL,
+			     response_body = NULL, created_at = CURRENT_TIMESTAMP, expires_at = EXCLUDED.expires_at
+			 WHERE idempotency_key.expires_at < CURRENT_TIMESTAMP
+			 RETURNING lease_token, expires_at`
+
+	record := model.IdempotencyRecord{
+		EmployeeID:  employeeID,
+		Key:         key,
+		RequestHash: requestHash,
+	}
+
+	err := r.db.QueryRow(ctx, stmt, employeeID, key, requestHash, lease.Seconds()).Scan(&record.LeaseToken, &record.ExpiresAt)
+	if err == nil {
+		return record, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		l.Error("Failed to reserve idempotency key", "error", err.Error())
+		return model.IdempotencyRecord{}, false, repository_idempotency.ErrInternal
+	}
+
+	stmt = `SELECT request_hash, status_code, response_headers, response_body, expires_at
+			FROM idempotency_key WHERE employee_id = $1 AND key = $2`
+
+	err = r.db.QueryRow(ctx, stmt, employeeID, key).
+		Scan(&record.RequestHash, &record.StatusCode, &record.ResponseHeaders, &record.ResponseBody, &record.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.IdempotencyRecord{}, false, repository_idempotency.ErrNoRecord
+		}
+
+		l.Error("Failed to get idempotency record", "error", err.Error())
+		return model.IdempotencyRecord{}, false, repository_idempotency.ErrInternal
+	}
+
+	return record, false, nil
+}
+
+func (r *rep) Complete(ctx context.Context, record model.IdempotencyRecord, statusCode int, headers map[string][]string, body []byte, ttl time.Duration) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := `UPDATE idempotency_key SET status_code = $5, response_headers = $6, response_body = $7,
+			 expires_at = CURRENT_TIMESTAMP + make_interval(secs => $8)
+			 WHERE employee_id = $1 AND key = $2 AND request_hash = $3 AND lease_token = $4 AND status_code IS NULL`
+
+	tag, err := r.db.Exec(ctx, stmt, record.EmployeeID, record.Key, record.RequestHash, record.LeaseToken, statusCode, headers, body, ttl.Seconds())
+	if err != nil {
+		l.Error("Failed to store idempotent response", "error", err.Error())
+		return repository_idempotency.ErrInternal
+	}
+
+	if tag.RowsAffected() == 0 {
+		return repository_idempotency.ErrLeaseLost
+	}
+
+	return nil
+}
+
+func (r *rep) Release(ctx context.Context, record model.IdempotencyRecord) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	//A KEY TAKEN OVER BY ANOTHER REQUEST IS LEFT TO IT
+	stmt := "DELETE FROM idempotency_key WHERE employee_id = $1 AND key = $2 AND lease_token = $3 AND status_code IS NULL"
+
+	if _, err := r.db.Exec(ctx, stmt, record.EmployeeID, record.Key, record.LeaseToken); err != nil {
+		l.Error("Failed to release idempotency key", "error", err.Error())
+		return repository_idempotency.ErrInternal
+	}
+
+	return nil
+}
+
+func (r *rep) DeleteExpired(ctx context.Context) (int64, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := "DELETE FROM idempotency_key WHERE expires_at < CURRENT_TIMESTAMP"
+
+	tag, err := r.db.Exec(ctx, stmt)
+	if err != nil {
+		l.Error("Failed to delete expired idempotency keys", "error", err.Error())
+		return 0, repository_idempotency.ErrInternal
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_idempotency.Repository {
+	return &rep{
+		db:     db,
+		logger: logger,
+	}
+}
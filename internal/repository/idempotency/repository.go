@@ -0,0 +1,19 @@
+package repository_idempotency
+
+import (
+	"avito_intership/internal/model"
+	"context"
+	"time"
+)
+
+type Repository interface {
+	//Reserve stores the key for the request for the lease unless a live record exists, which is returned instead.
+	//An expired record, a request that never finished included, is taken over
+	Reserve(ctx context.Context, employeeID, key, requestHash string, lease time.Duration) (record model.IdempotencyRecord, reserved bool, err error)
+	//Complete stores the response of the request that holds the lease of the record and keeps it for the ttl.
+	//ErrLeaseLost is returned once the key has been taken over by another request
+	Complete(ctx context.Context, record model.IdempotencyRecord, statusCode int, headers map[string][]string, body []byte, ttl time.Duration) error
+	Release(ctx context.Context, record model.IdempotencyRecord) error
+	//DeleteExpired removes the records that can no longer be replayed and returns how many there were
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,73 @@
+package repository_organization_postgres
+
+import (
+	"avito_intership/internal/model"
+	repository_organization "avito_intership/internal/repository/organization"
+	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+)
+
+const organizationColumns = "id, name, description, type, created_at, updated_at"
+
+type rep struct {
+	db     *postgres.DB
+	logger *slog.Logger
+}
+
+func scanOrganization(row pgx.Row) (organization model.Organization, err error) {
+	err = row.Scan(&organization.ID,
+		&organization.Name,
+		&organization.Description,
+		&organization.Type,
+		&organization.CreatedAt,
+		&organization.UpdatedAt)
+	return organization, err
+}
+
+func (r *rep) Create(ctx context.Context, organization model.Organization) (model.Organization, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf(`INSERT INTO organization (name, description, type) VALUES ($1, $2, $3)
+			 RETURNING %s`, organizationColumns)
+
+	created, err := scanOrganization(r.db.QueryRow(ctx, stmt, organization.Name, organization.Description, organization.Type))
+	if err != nil {
+		l.Error("Failed to create organization", "error", err.Error())
+		return model.Organization{}, repository_organization.ErrInternal
+	}
+
+	return created, nil
+}
+
+func (r *rep) Organization(ctx context.Context, organizationID string) (model.Organization, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf("SELECT %s FROM organization WHERE id = $1", organizationColumns)
+
+	organization, err := scanOrganization(r.db.QueryRow(ctx, stmt, organizationID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Organization{}, repository_organization.ErrNoOrganization
+		}
+
+		l.Error("Failed to get organization", "error", err.Error())
+		return model.Organization{}, repository_organization.ErrInternal
+	}
+
+	return organization, nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_organization.Repository {
+	r := &rep{
+		db:     db,
+		logger: logger,
+	}
+
+	return r
+}
@@ -5,4 +5,7 @@ import "errors"
 var (
 	ErrInternal              = errors.New("internal error")
 	ErrUserHasNoOrganization = errors.New("the user does not have an organization")
+	ErrAlreadyRepresentative = errors.New("the user already represents the organization")
+	ErrNotRepresentative     = errors.New("the user does not represent the organization")
+	ErrInvalidReference      = errors.New("organization or user does not exist")
 )
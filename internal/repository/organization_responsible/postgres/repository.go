@@ -1,44 +1,64 @@
 package repository_organization_resp_postgres
 
 import (
-	"avito_intership/internal/repository"
+	"avito_intership/internal/model"
 	repository_organization_resp "avito_intership/internal/repository/organization_responsible"
 	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
 	"context"
-	"database/sql"
 	"errors"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	"log/slog"
 )
 
 type rep struct {
-	pool   *pgxpool.Pool
+	db     *postgres.DB
 	logger *slog.Logger
 }
 
-func (r *rep) GetOrganizationIDByRepresentative(ctx context.Context, userID string) (organizationID string, err error) {
+func (r *rep) OrganizationIDsByRepresentative(ctx context.Context, userID string) (organizationIDs []string, err error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := "SELECT organization_id FROM organization_responsible WHERE user_id = $1"
+	stmt := "SELECT organization_id FROM organization_responsible WHERE user_id = $1 ORDER BY organization_id"
 
-	if err = r.pool.QueryRow(ctx, stmt, userID).Scan(&organizationID); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", repository_organization_resp.ErrUserHasNoOrganization
+	rows, err := r.db.Query(ctx, stmt, userID)
+	if err != nil {
+		l.Error("Failed to get organization ids by representative", "error", err.Error())
+		return nil, repository_organization_resp.ErrInternal
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var organizationID string
+		if err = rows.Scan(&organizationID); err != nil {
+			l.Error("Failed to scan organization id", "error", err.Error())
+			return nil, repository_organization_resp.ErrInternal
 		}
+		organizationIDs = append(organizationIDs, organizationID)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Error("Failed to get organization ids by representative", "error", err.Error())
+		return nil, repository_organization_resp.ErrInternal
+	}
 
-		l.Error("Failed to get organization id by representative", "error", err.Error())
-		return "", repository_organization_resp.ErrInternal
+	if len(organizationIDs) == 0 {
+		return nil, repository_organization_resp.ErrUserHasNoOrganization
 	}
 
-	return organizationID, nil
+	return organizationIDs, nil
 }
 
 func (r *rep) OrganizationRepresentativesAmount(ctx context.Context, organizationID string) (amount int, err error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := "SELECT COUNT(*) FROM organization_responsible WHERE organization_id = $1"
+	//DEACTIVATED EMPLOYEES CAN NOT VOTE, SO THEY DO NOT COUNT TOWARDS THE QUORUM
+	stmt := `SELECT COUNT(*) FROM organization_responsible r
+			 JOIN employee e ON e.id = r.user_id
+			 WHERE r.organization_id = $1 AND e.deactivated_at IS NULL`
 
-	if err = r.pool.QueryRow(ctx, stmt, organizationID).Scan(&amount); err != nil {
+	if err = r.db.QueryRow(ctx, stmt, organizationID).Scan(&amount); err != nil {
 		l.Error("Failed to count organization representatives", "error", err.Error())
 		return 0, repository_organization_resp.ErrInternal
 	}
@@ -46,26 +66,108 @@ func (r *rep) OrganizationRepresentativesAmount(ctx context.Context, organizatio
 	return amount, nil
 }
 
-func (r *rep) CloseConn() {
-	r.pool.Close()
+func (r *rep) Representatives(ctx context.Context, organizationID string) ([]model.Employee, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := `SELECT e.id, e.username, e.first_name, e.last_name, e.deactivated_at, e.created_at, e.updated_at
+			 FROM organization_responsible r
+			 JOIN employee e ON e.id = r.user_id
+			 WHERE r.organization_id = $1
+			 ORDER BY e.username`
+
+	rows, err := r.db.Query(ctx, stmt, organizationID)
+	if err != nil {
+		l.Error("Failed to get organization representatives", "error", err.Error())
+		return nil, repository_organization_resp.ErrInternal
+	}
+	defer rows.Close()
+
+	representatives := make([]model.Employee, 0)
+	for rows.Next() {
+		var employee model.Employee
+		if err = rows.Scan(&employee.ID,
+			&employee.Username,
+			&employee.FirstName,
+			&employee.LastName,
+			&employee.DeactivatedAt,
+			&employee.CreatedAt,
+			&employee.UpdatedAt); err != nil {
+			l.Error("Failed to scan organization representative", "error", err.Error())
+			return nil, repository_organization_resp.ErrInternal
+		}
+		representatives = append(representatives, employee)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Error("Failed to get organization representatives", "error", err.Error())
+		return nil, repository_organization_resp.ErrInternal
+	}
+
+	return representatives, nil
+}
+
+func (r *rep) AddRepresentative(ctx context.Context, organizationID string, userID string) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := "INSERT INTO organization_responsible (organization_id, user_id) VALUES ($1, $2)"
+
+	_, err := r.db.Exec(ctx, stmt, organizationID, userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch {
+			case pgErr.Code == pgerrcode.UniqueViolation:
+				return repository_organization_resp.ErrAlreadyRepresentative
+			case pgErr.Code == pgerrcode.ForeignKeyViolation:
+				return repository_organization_resp.ErrInvalidReference
+			}
+		}
+
+		l.Error("Failed to add organization representative", "error", err.Error())
+		return repository_organization_resp.ErrInternal
+	}
+
+	return nil
 }
 
-func New(ctx context.Context, connStr string, logger *slog.Logger) (repository_organization_resp.Repository, error) {
-	pool, err := pgxpool.New(ctx, connStr)
+func (r *rep) RemoveRepresentative(ctx context.Context, organizationID string, userID string) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := "DELETE FROM organization_responsible WHERE organization_id = $1 AND user_id = $2"
+
+	tag, err := r.db.Exec(ctx, stmt, organizationID, userID)
 	if err != nil {
-		logger.Error("Failed to open connection to db", "error", err.Error())
-		return nil, repository.ErrOpenConn
+		l.Error("Failed to remove organization representative", "error", err.Error())
+		return repository_organization_resp.ErrInternal
 	}
 
-	if err = pool.Ping(ctx); err != nil {
-		logger.Error("Failed to ping db", "error", err.Error())
-		return nil, repository.ErrPingDB
+	if tag.RowsAffected() == 0 {
+		return repository_organization_resp.ErrNotRepresentative
 	}
 
+	return nil
+}
+
+func (r *rep) IsApprover(ctx context.Context, organizationIDs []string, userID string) (bool, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := `SELECT EXISTS (SELECT 1 FROM tender
+			 WHERE organization_id = ANY($1) AND approver_id = $2 AND decision_policy = 'SingleApprover' AND status <> 'Closed')`
+
+	var isApprover bool
+	if err := r.db.QueryRow(ctx, stmt, organizationIDs, userID).Scan(&isApprover); err != nil {
+		l.Error("Failed to check tender approvers", "error", err.Error())
+		return false, repository_organization_resp.ErrInternal
+	}
+
+	return isApprover, nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_organization_resp.Repository {
 	r := &rep{
-		pool:   pool,
+		db:     db,
 		logger: logger,
 	}
 
-	return r, nil
+	return r
 }
@@ -1,9 +1,19 @@
 package repository_organization_resp
 
-import "context"
+import (
+	"avito_intership/internal/model"
+	"context"
+)
 
 type Repository interface {
-	GetOrganizationIDByRepresentative(ctx context.Context, userID string) (organizationID string, err error)
+	//OrganizationIDsByRepresentative lists the organizations the user represents
+	OrganizationIDsByRepresentative(ctx context.Context, userID string) (organizationIDs []string, err error)
+	//OrganizationRepresentativesAmount counts active representatives only
 	OrganizationRepresentativesAmount(ctx context.Context, organizationID string) (amount int, err error)
-	CloseConn()
+	//Representatives lists every representative of the organization, deactivated ones included
+	Representatives(ctx context.Context, organizationID string) ([]model.Employee, error)
+	AddRepresentative(ctx context.Context, organizationID string, userID string) error
+	RemoveRepresentative(ctx context.Context, organizationID string, userID string) error
+	//IsApprover reports whether the user is the approver of a SingleApprover tender of the organizations that is not closed yet
+	IsApprover(ctx context.Context, organizationIDs []string, userID string) (bool, error)
 }
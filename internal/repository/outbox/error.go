@@ -0,0 +1,7 @@
+package repository_outbox
+
+import "errors"
+
+var (
+	ErrInternal = errors.New("internal error")
+)
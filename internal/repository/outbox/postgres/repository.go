@@ -0,0 +1,121 @@
+package repository_outbox_postgres
+
+import (
+	"avito_intership/internal/model"
+	repository_outbox "avito_intership/internal/repository/outbox"
+	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+type rep struct {
+	db     *postgres.DB
+	logger *slog.Logger
+}
+
+func (r *rep) Add(ctx context.Context, event model.Event) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := "INSERT INTO outbox_event (event_type, aggregate_type, aggregate_id, organization_ids, payload) VALUES ($1, $2, $3, $4, $5)"
+
+	//A NIL SLICE IS SENT AS NULL, THE COLUMN EXPECTS AN EMPTY ARRAY
+	organizationIDs := event.OrganizationIDs
+	if organizationIDs == nil {
+		organizationIDs = []string{}
+	}
+
+	if _, err := r.db.Exec(ctx, stmt, event.Type, event.AggregateType, event.AggregateID, organizationIDs, event.Payload); err != nil {
+		l.Error("Failed to add outbox event", "error", err.Error())
+		return repository_outbox.ErrInternal
+	}
+
+	return nil
+}
+
+func (r *rep) Claim(ctx context.Context, limit int, maxAttempts int, lease time.Duration) ([]model.Event, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	//SKIP LOCKED LETS ANOTHER DISPATCHER CLAIM THE NEXT EVENTS INSTEAD OF WAITING FOR THESE
+	stmt := `UPDATE outbox_event SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $3)
+			 WHERE id IN (
+			     SELECT id FROM outbox_event
+			     WHERE dispatched_at IS NULL AND attempts < $2 AND next_attempt_at <= CURRENT_TIMESTAMP
+			     ORDER BY created_at
+			     LIMIT $1
+			     FOR UPDATE SKIP LOCKED)
+			 RETURNING id, event_type, aggregate_type, aggregate_id, organization_ids, payload, created_at, attempts`
+
+	rows, err := r.db.Query(ctx, stmt, limit, maxAttempts, lease.Seconds())
+	if err != nil {
+		l.Error("Failed to claim outbox events", "error", err.Error())
+		return nil, repository_outbox.ErrInternal
+	}
+	defer rows.Close()
+
+	events := make([]model.Event, 0)
+	for rows.Next() {
+		event := model.Event{}
+		if err = rows.Scan(&event.ID,
+			&event.Type,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.OrganizationIDs,
+			&event.Payload,
+			&event.CreatedAt,
+			&event.Attempts); err != nil {
+			l.Error("Failed to scan outbox event", "error", err.Error())
+			return nil, repository_outbox.ErrInternal
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Error("Failed to claim outbox events", "error", err.Error())
+		return nil, repository_outbox.ErrInternal
+	}
+
+	//RETURNING KEEPS NO ORDER, EVENTS ARE DELIVERED IN THE ORDER THEY HAPPENED
+	slices.SortFunc(events, func(a, b model.Event) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return events, nil
+}
+
+func (r *rep) MarkDispatched(ctx context.Context, eventID string) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := "UPDATE outbox_event SET dispatched_at = CURRENT_TIMESTAMP, attempts = attempts + 1, last_error = NULL WHERE id = $1"
+
+	if _, err := r.db.Exec(ctx, stmt, eventID); err != nil {
+		l.Error("Failed to mark outbox event dispatched", "error", err.Error())
+		return repository_outbox.ErrInternal
+	}
+
+	return nil
+}
+
+func (r *rep) MarkFailed(ctx context.Context, eventID string, retryIn time.Duration, lastError string) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := `UPDATE outbox_event
+			 SET attempts = attempts + 1, last_error = $3, next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+			 WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, stmt, eventID, retryIn.Seconds(), lastError); err != nil {
+		l.Error("Failed to mark outbox event failed", "error", err.Error())
+		return repository_outbox.ErrInternal
+	}
+
+	return nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_outbox.Repository {
+	return &rep{
+		db:     db,
+		logger: logger,
+	}
+}
@@ -0,0 +1,17 @@
+package repository_outbox
+
+import (
+	"avito_intership/internal/model"
+	"context"
+	"time"
+)
+
+type Repository interface {
+	//Add writes the event to the outbox, it commits or rolls back together with the surrounding transaction
+	Add(ctx context.Context, event model.Event) error
+	//Claim leases up to limit due events for the lease duration, so concurrent dispatchers skip them meanwhile
+	Claim(ctx context.Context, limit int, maxAttempts int, lease time.Duration) ([]model.Event, error)
+	MarkDispatched(ctx context.Context, eventID string) error
+	//MarkFailed counts the attempt and postpones the next one by retryIn
+	MarkFailed(ctx context.Context, eventID string, retryIn time.Duration, lastError string) error
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"avito_intership/internal/model"
+	"avito_intership/pkg/postgres"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// KeysetCondition returns the predicate that skips rows up to and including
+// the page cursor in the (created_at DESC, id DESC) ordering, or an empty string
+// when the page has no cursor.
+func KeysetCondition(page model.Page, placeholder func(arg interface{}) string) string {
+	if page.After == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("(created_at, id) < (%s::timestamp, %s::uuid)", placeholder(page.After.CreatedAt), placeholder(page.After.ID))
+}
+
+// LimitOffset returns the LIMIT/OFFSET clause for the page. One extra row is
+// requested so that NextPage can tell whether another page exists.
+func LimitOffset(page model.Page, placeholder func(arg interface{}) string) string {
+	offset := page.Offset
+	if page.After != nil {
+		offset = 0
+	}
+
+	return fmt.Sprintf("LIMIT %s OFFSET %s", placeholder(page.Limit+1), placeholder(offset))
+}
+
+// NextPage trims the extra row fetched by LimitOffset and builds the cursor
+// pointing at the last returned item.
+func NextPage[T any](items []T, page model.Page, key func(item T) model.Cursor) ([]T, *model.Cursor) {
+	if len(items) <= page.Limit {
+		return items, nil
+	}
+
+	items = items[:page.Limit]
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	cursor := key(items[len(items)-1])
+	return items, &cursor
+}
+
+// Total counts the rows matched by where. Keyset and limit arguments must not be part of args.
+func Total(ctx context.Context, db *postgres.DB, table string, where string, args []interface{}) (*int, error) {
+	var total int
+	if err := db.QueryRow(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s %s", table, where), args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	return &total, nil
+}
+
+// Where joins the non-empty conditions into a WHERE clause.
+func Where(conditions ...string) string {
+	nonEmpty := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		if condition != "" {
+			nonEmpty = append(nonEmpty, condition)
+		}
+	}
+
+	if len(nonEmpty) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(nonEmpty, " AND ")
+}
@@ -0,0 +1,8 @@
+package repository_supplier
+
+import "errors"
+
+var (
+	ErrInternal   = errors.New("internal error")
+	ErrNoSupplier = errors.New("no supplier")
+)
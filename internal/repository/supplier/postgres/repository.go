@@ -0,0 +1,73 @@
+package repository_supplier_postgres
+
+import (
+	"avito_intership/internal/model"
+	repository_supplier "avito_intership/internal/repository/supplier"
+	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+)
+
+type rep struct {
+	db     *postgres.DB
+	logger *slog.Logger
+}
+
+func (r *rep) Author(ctx context.Context, authorID string) (author model.BidAuthor, name string, err error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := `SELECT 'Organization', name FROM organization WHERE id = $1
+			 UNION ALL
+			 SELECT 'User', username FROM employee WHERE id = $1
+			 LIMIT 1`
+
+	author.ID = authorID
+	if err = r.db.QueryRow(ctx, stmt, authorID).Scan(&author.Type, &name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.BidAuthor{}, "", repository_supplier.ErrNoSupplier
+		}
+
+		l.Error("Failed to get supplier", "error", err.Error())
+		return model.BidAuthor{}, "", repository_supplier.ErrInternal
+	}
+
+	return author, name, nil
+}
+
+func (r *rep) BidStats(ctx context.Context, author model.BidAuthor) (model.SupplierProfile, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	//A CANCELED BID COUNTS AS SUBMITTED WHEN ONE OF ITS ARCHIVED VERSIONS WAS PUBLISHED
+	stmt := `SELECT
+			     (SELECT COUNT(*) FROM bid
+			      WHERE author_type = $1 AND author_id = $2
+			        AND (status = 'Published' OR EXISTS (SELECT 1 FROM bid_history WHERE bid_history.id = bid.id AND bid_history.status = 'Published'))),
+			     (SELECT COUNT(*) FROM tender_award WHERE author_type = $1 AND author_id = $2),
+			     (SELECT COUNT(*) FROM decision JOIN bid ON bid.id = decision.bid_id
+			      WHERE bid.author_type = $1 AND bid.author_id = $2 AND decision.decision = 'Approved'),
+			     (SELECT COUNT(*) FROM decision JOIN bid ON bid.id = decision.bid_id
+			      WHERE bid.author_type = $1 AND bid.author_id = $2 AND decision.decision = 'Rejected')`
+
+	profile := model.SupplierProfile{Author: author}
+	if err := r.db.QueryRow(ctx, stmt, author.Type, author.ID).Scan(&profile.BidsSubmitted,
+		&profile.BidsWon,
+		&profile.Approvals,
+		&profile.Rejections); err != nil {
+		l.Error("Failed to get supplier bid stats", "error", err.Error())
+		return model.SupplierProfile{}, repository_supplier.ErrInternal
+	}
+
+	return profile, nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_supplier.Repository {
+	r := &rep{
+		db:     db,
+		logger: logger,
+	}
+
+	return r
+}
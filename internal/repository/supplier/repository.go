@@ -0,0 +1,13 @@
+package repository_supplier
+
+import (
+	"avito_intership/internal/model"
+	"context"
+)
+
+type Repository interface {
+	//Author tells whether the id belongs to an organization or to a user and returns its name
+	Author(ctx context.Context, authorID string) (author model.BidAuthor, name string, err error)
+	//BidStats fills in the bid counters of the profile
+	BidStats(ctx context.Context, author model.BidAuthor) (model.SupplierProfile, error)
+}
@@ -6,6 +6,16 @@ import (
 )
 
 func ToTenderFromRepository(tender repository_tender_model.Tender) model.Tender {
+	var policy *model.DecisionPolicy
+	if tender.DecisionPolicy != "" {
+		policy = &model.DecisionPolicy{
+			Type:              tender.DecisionPolicy,
+			RequiredApprovals: tender.RequiredApprovals,
+			ApproverID:        tender.ApproverID,
+			RejectionVeto:     tender.RejectionVeto,
+		}
+	}
+
 	return model.Tender{
 		ID:          &tender.ID,
 		Name:        &tender.Name,
@@ -14,5 +24,16 @@ func ToTenderFromRepository(tender repository_tender_model.Tender) model.Tender
 		Status:      &tender.Status,
 		Version:     &tender.Version,
 		CreatedAt:   &tender.CreatedAt,
+		ChangedBy:   tender.ChangedBy,
+		ChangeKind:  tender.ChangeKind,
+
+		DecisionPolicy: policy,
+	}
+}
+
+func ToTenderVersionFromRepository(version repository_tender_model.TenderVersion) model.TenderVersion {
+	return model.TenderVersion{
+		Tender:    ToTenderFromRepository(version.Tender),
+		ChangedAt: version.ChangedAt,
 	}
 }
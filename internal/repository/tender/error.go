@@ -7,4 +7,6 @@ var (
 	ErrNoTenders            = errors.New("no tender")
 	ErrInvalidStatus        = errors.New("invalid status")
 	ErrNoSuggestionToUpdate = errors.New("no suggestion to update")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+	ErrVersionConflict      = errors.New("version conflict")
 )
@@ -10,4 +10,11 @@ type Tender struct {
 	ServiceType string
 	Version     int
 	CreatedAt   time.Time
+	ChangedBy   *string
+	ChangeKind  *string
+
+	DecisionPolicy    string
+	RequiredApprovals *int
+	ApproverID        *string
+	RejectionVeto     bool
 }
@@ -0,0 +1,8 @@
+package repository_tender_model
+
+import "time"
+
+type TenderVersion struct {
+	Tender
+	ChangedAt time.Time
+}
@@ -7,114 +7,127 @@ import (
 	repository_tender_converter "avito_intership/internal/repository/tender/converter"
 	repository_tender_model "avito_intership/internal/repository/tender/model"
 	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
 	"avito_intership/pkg/sql_patch"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"log/slog"
 	"strings"
 )
 
 type rep struct {
-	pool   *pgxpool.Pool
+	db     *postgres.DB
 	logger *slog.Logger
 }
 
-func (r *rep) TenderOrganizationID(ctx context.Context, tenderID string) (organizationID string, err error) {
+func (r *rep) TenderList(ctx context.Context, filter model.TenderFilter, page model.Page) ([]model.Tender, model.PageInfo, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := "SELECT organization_id FROM tender WHERE id = $1"
+	args := make([]interface{}, 0)
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
 
-	if err = r.pool.QueryRow(ctx, stmt, tenderID).Scan(&organizationID); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", repository_tenders.ErrNoTenders
-		}
-		l.Error("Failed to get tender organization id", "error", err.Error())
-		return "", repository_tenders.ErrInternal
+	conditions := make([]string, 0)
+	if filter.ServiceTypes != nil {
+		conditions = append(conditions, fmt.Sprintf("service_type::text = ANY(%s)", placeholder(filter.ServiceTypes)))
+	}
+	if filter.Statuses != nil {
+		conditions = append(conditions, fmt.Sprintf("status::text = ANY(%s)", placeholder(filter.Statuses)))
+	}
+	if filter.OrganizationID != "" {
+		conditions = append(conditions, fmt.Sprintf("organization_id = %s", placeholder(filter.OrganizationID)))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= %s", placeholder(*filter.CreatedFrom)))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < %s", placeholder(*filter.CreatedTo)))
 	}
 
-	return organizationID, nil
-}
+	//DRAFTS ARE LISTED FOR THE OWNING ORGANIZATION ONLY
+	if len(filter.ViewerOrganizationIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf("(status <> 'Created' OR organization_id = ANY(%s))", placeholder(filter.ViewerOrganizationIDs)))
+	} else {
+		conditions = append(conditions, "status <> 'Created'")
+	}
 
-func (r *rep) TenderList(ctx context.Context, serviceTypes []string, limit int, offset int) ([]model.Tender, error) {
-	l := logger.EndToEndLogging(ctx, r.logger)
+	//FULL-TEXT QUERY IS MATCHED AGAINST THE GIN-INDEXED search_vector
+	var query string
+	if filter.Query != "" {
+		query = placeholder(filter.Query)
+		conditions = append(conditions, fmt.Sprintf("search_vector @@ websearch_to_tsquery('simple', %s)", query))
+	}
 
-	args := make([]interface{}, 0)
-	var condition string
-	switch {
-	case serviceTypes != nil:
-		condition = "WHERE service_type IN ("
-		for index, serviceType := range serviceTypes {
-			condition = fmt.Sprintf("%s $%d", condition, index+1)
-			if index != len(serviceTypes)-1 {
-				condition += ","
-			}
-			args = append(args, serviceType)
+	pageInfo := model.PageInfo{}
+	if page.WithTotal {
+		total, err := repository.Total(ctx, r.db, "tender", repository.Where(conditions...), args)
+		if err != nil {
+			l.Error("Failed to count tenders", "error", err.Error())
+			return nil, model.PageInfo{}, repository_tenders.ErrInternal
 		}
-		condition = fmt.Sprintf("%s) LIMIT $%d OFFSET $%d", condition, len(serviceTypes)+1, len(serviceTypes)+2)
-		args = append(args, limit, offset)
-	default:
-		condition = "LIMIT $1 OFFSET $2"
-		args = append(args, limit, offset)
+		pageInfo.Total = total
 	}
 
-	stmt := fmt.Sprintf("SELECT id, name, description, status, service_type, version, created_at FROM tender %s", condition)
+	//ONLY THE NEWEST-FIRST ORDER IS STABLE ACROSS PAGES FOR KEYSET PAGINATION
+	keyset := true
+	var orderBy string
+	switch {
+	case filter.Sort == model.TenderSortName:
+		orderBy, keyset = "name ASC, id ASC", false
+	case filter.Sort == "" && query != "":
+		orderBy, keyset = fmt.Sprintf("ts_rank(search_vector, websearch_to_tsquery('simple', %s)) DESC, created_at DESC, id DESC", query), false
+	default:
+		orderBy = "created_at DESC, id DESC"
+	}
 
-	rows, err := r.pool.Query(ctx, stmt, args...)
-	if err != nil {
-		l.Error("Failed to get tender list", "error", err.Error())
-		return nil, repository_tenders.ErrInternal
+	if page.After != nil && !keyset {
+		return nil, model.PageInfo{}, repository_tenders.ErrInvalidCursor
 	}
-	defer rows.Close()
 
-	tenders := make([]model.Tender, 0, limit)
+	conditions = append(conditions, repository.KeysetCondition(page, placeholder))
 
-	for rows.Next() {
-		tender := repository_tender_model.Tender{}
-		if err = rows.Scan(&tender.ID,
-			&tender.Name,
-			&tender.Description,
-			&tender.Status,
-			&tender.ServiceType,
-			&tender.Version,
-			&tender.CreatedAt); err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, repository_tenders.ErrNoTenders
-			}
-			l.Error("Failed to get tender list", "error", err.Error())
-			return nil, repository_tenders.ErrInternal
-		}
-		tenders = append(tenders, repository_tender_converter.ToTenderFromRepository(tender))
-	}
+	stmt := fmt.Sprintf("SELECT %s FROM tender %s ORDER BY %s %s", tenderColumns,
+		repository.Where(conditions...), orderBy, repository.LimitOffset(page, placeholder))
 
-	if err = rows.Err(); err != nil {
+	tenders, err := r.tenders(ctx, stmt, args...)
+	if err != nil {
 		l.Error("Failed to get tender list", "error", err.Error())
-		return nil, repository_tenders.ErrInternal
+		return nil, model.PageInfo{}, repository_tenders.ErrInternal
 	}
 
-	return tenders, nil
+	tenders, pageInfo.NextCursor = repository.NextPage(tenders, page, tenderCursor)
+	if !keyset {
+		pageInfo.NextCursor = nil
+	}
+
+	return tenders, pageInfo, nil
 }
 
 func (r *rep) Create(ctx context.Context, tender model.Tender) (model.Tender, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := `INSERT INTO tender (name, description, service_type, organization_id, creator_username) VALUES ($1,$2,$3,$4,$5)
-RETURNING id, name, description, status, service_type, version, created_at`
+	//TENDERS CREATED WITHOUT A POLICY ARE DECIDED THE WAY THEY ALWAYS WERE
+	policy := model.DefaultDecisionPolicy()
+	if tender.DecisionPolicy != nil {
+		policy = *tender.DecisionPolicy
+	}
+
+	stmt := fmt.Sprintf(`INSERT INTO tender (name, description, service_type, organization_id, creator_username, changed_by, change_kind,
+			 decision_policy, required_approvals, approver_id, rejection_veto) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+RETURNING %s`, tenderColumns)
 
-	row := r.pool.QueryRow(ctx, stmt, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername)
+	row := r.db.QueryRow(ctx, stmt, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername,
+		tender.ChangedBy, model.ChangeKindCreate, policy.Type, policy.RequiredApprovals, policy.ApproverID, policy.RejectionVeto)
 
-	repoTender := repository_tender_model.Tender{}
-	if err := row.Scan(&repoTender.ID,
-		&repoTender.Name,
-		&repoTender.Description,
-		&repoTender.Status,
-		&repoTender.ServiceType,
-		&repoTender.Version,
-		&repoTender.CreatedAt); err != nil {
+	repoTender, err := scanTender(row)
+	if err != nil {
 		l.Error("Failed to create tender", "error", err.Error())
 		return model.Tender{}, repository_tenders.ErrInternal
 	}
@@ -122,54 +135,96 @@ RETURNING id, name, description, status, service_type, version, created_at`
 	return repository_tender_converter.ToTenderFromRepository(repoTender), nil
 }
 
-func (r *rep) TendersByUser(ctx context.Context, username string, limit int, offset int) ([]model.Tender, error) {
+func (r *rep) TendersByUser(ctx context.Context, username string, page model.Page) ([]model.Tender, model.PageInfo, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := "SELECT id, name, description, status, service_type, version, created_at FROM tender WHERE creator_username = $1 LIMIT $2 OFFSET $3"
+	args := make([]interface{}, 0)
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	condition := fmt.Sprintf("creator_username = %s", placeholder(username))
+
+	pageInfo := model.PageInfo{}
+	if page.WithTotal {
+		total, err := repository.Total(ctx, r.db, "tender", repository.Where(condition), args)
+		if err != nil {
+			l.Error("Failed to count tenders by user", "error", err.Error())
+			return nil, model.PageInfo{}, repository_tenders.ErrInternal
+		}
+		pageInfo.Total = total
+	}
+
+	stmt := fmt.Sprintf("SELECT %s FROM tender %s ORDER BY created_at DESC, id DESC %s", tenderColumns,
+		repository.Where(condition, repository.KeysetCondition(page, placeholder)), repository.LimitOffset(page, placeholder))
 
-	rows, err := r.pool.Query(ctx, stmt, username, limit, offset)
+	tenders, err := r.tenders(ctx, stmt, args...)
 	if err != nil {
 		l.Error("Failed to get tender list by user", "error", err.Error())
-		return nil, repository_tenders.ErrInternal
+		return nil, model.PageInfo{}, repository_tenders.ErrInternal
+	}
+
+	tenders, pageInfo.NextCursor = repository.NextPage(tenders, page, tenderCursor)
+
+	return tenders, pageInfo, nil
+}
+
+func (r *rep) tenders(ctx context.Context, stmt string, args ...interface{}) ([]model.Tender, error) {
+	rows, err := r.db.Query(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
 	}
 	defer rows.Close()
 
 	tenders := make([]model.Tender, 0)
 
 	for rows.Next() {
-		tender := repository_tender_model.Tender{}
-		if err = rows.Scan(&tender.ID,
-			&tender.Name,
-			&tender.Description,
-			&tender.Status,
-			&tender.ServiceType,
-			&tender.Version,
-			&tender.CreatedAt); err != nil {
-
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, repository_tenders.ErrNoTenders
-			}
-
-			l.Error("Failed to get tender list by user", "error", err.Error())
-			return nil, repository_tenders.ErrInternal
+		tender, err := scanTender(rows)
+		if err != nil {
+			return nil, err
 		}
 		tenders = append(tenders, repository_tender_converter.ToTenderFromRepository(tender))
 	}
 
 	if err = rows.Err(); err != nil {
-		l.Error("Failed to get tender list by user", "error", err.Error())
-		return nil, repository_tenders.ErrInternal
+		return nil, err
 	}
 
 	return tenders, nil
 }
 
+// tenderColumns are read by scanTender
+const tenderColumns = "id, name, description, status, service_type, version, created_at, changed_by, change_kind, decision_policy, required_approvals, approver_id, rejection_veto"
+
+func scanTender(row pgx.Row) (tender repository_tender_model.Tender, err error) {
+	err = row.Scan(&tender.ID,
+		&tender.Name,
+		&tender.Description,
+		&tender.Status,
+		&tender.ServiceType,
+		&tender.Version,
+		&tender.CreatedAt,
+		&tender.ChangedBy,
+		&tender.ChangeKind,
+		&tender.DecisionPolicy,
+		&tender.RequiredApprovals,
+		&tender.ApproverID,
+		&tender.RejectionVeto)
+	return tender, err
+}
+
+// tenderCursor keys the page on created_at, which is NOT NULL since migration 19
+func tenderCursor(tender model.Tender) model.Cursor {
+	return model.Cursor{CreatedAt: *tender.CreatedAt, ID: *tender.ID}
+}
+
 func (r *rep) TenderStatus(ctx context.Context, tenderID string) (tenderOrganizationID string, status string, err error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
 	stmt := "SELECT organization_id, status FROM tender WHERE id = $1"
 
-	if err = r.pool.QueryRow(ctx, stmt, tenderID).Scan(&tenderOrganizationID, &status); err != nil {
+	if err = r.db.QueryRow(ctx, stmt, tenderID).Scan(&tenderOrganizationID, &status); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", "", repository_tenders.ErrNoTenders
 		}
@@ -181,20 +236,52 @@ func (r *rep) TenderStatus(ctx context.Context, tenderID string) (tenderOrganiza
 	return tenderOrganizationID, status, nil
 }
 
-func (r *rep) ChangeTenderStatusWithUserCheck(ctx context.Context, tenderID string, username string, status string) (model.Tender, error) {
+func (r *rep) TenderStatusForUpdate(ctx context.Context, tenderID string) (tenderOrganizationID string, status string, err error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := `UPDATE tender SET status = $1 WHERE id = $2 AND creator_username = $3
-RETURNING id, name, description, status, service_type, version, created_at`
+	stmt := "SELECT organization_id, status FROM tender WHERE id = $1 FOR UPDATE"
 
-	tender := repository_tender_model.Tender{}
-	if err := r.pool.QueryRow(ctx, stmt, status, tenderID, username).Scan(&tender.ID,
-		&tender.Name,
-		&tender.Description,
-		&tender.Status,
-		&tender.ServiceType,
-		&tender.Version,
-		&tender.CreatedAt); err != nil {
+	if err = r.db.QueryRow(ctx, stmt, tenderID).Scan(&tenderOrganizationID, &status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", repository_tenders.ErrNoTenders
+		}
+
+		l.Error("Failed to lock tender", "error", err.Error())
+		return "", "", repository_tenders.ErrInternal
+	}
+
+	return tenderOrganizationID, status, nil
+}
+
+func (r *rep) DecisionPolicy(ctx context.Context, tenderID string) (model.DecisionPolicy, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := "SELECT decision_policy, required_approvals, approver_id, rejection_veto FROM tender WHERE id = $1"
+
+	policy := model.DecisionPolicy{}
+	if err := r.db.QueryRow(ctx, stmt, tenderID).Scan(&policy.Type,
+		&policy.RequiredApprovals,
+		&policy.ApproverID,
+		&policy.RejectionVeto); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.DecisionPolicy{}, repository_tenders.ErrNoTenders
+		}
+
+		l.Error("Failed to get tender decision policy", "error", err.Error())
+		return model.DecisionPolicy{}, repository_tenders.ErrInternal
+	}
+
+	return policy, nil
+}
+
+func (r *rep) ChangeTenderStatus(ctx context.Context, tenderID string, status string, changedBy string) (model.Tender, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf(`UPDATE tender SET status = $1, changed_by = $3, change_kind = 'StatusChange' WHERE id = $2
+RETURNING %s`, tenderColumns)
+
+	tender, err := scanTender(r.db.QueryRow(ctx, stmt, status, tenderID, changedBy))
+	if err != nil {
 
 		var pgErr *pgconn.PgError
 		switch {
@@ -214,13 +301,12 @@ RETURNING id, name, description, status, service_type, version, created_at`
 	return repository_tender_converter.ToTenderFromRepository(tender), nil
 }
 
-func (r *rep) ChangeTenderStatusForce(ctx context.Context, tenderID string, status string) error {
+func (r *rep) ChangeTenderStatusForce(ctx context.Context, tenderID string, status string, changedBy string) error {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := `UPDATE tender SET status = $1 WHERE id = $2
-RETURNING id, name, description, status, service_type, version, created_at`
+	stmt := "UPDATE tender SET status = $1, changed_by = NULLIF($3, '')::UUID, change_kind = 'StatusChange' WHERE id = $2"
 
-	if _, err := r.pool.Exec(ctx, stmt, status, tenderID); err != nil {
+	if _, err := r.db.Exec(ctx, stmt, status, tenderID, changedBy); err != nil {
 		var pgErr *pgconn.PgError
 		switch {
 		case errors.As(err, &pgErr):
@@ -239,29 +325,66 @@ RETURNING id, name, description, status, service_type, version, created_at`
 	return nil
 }
 
-func (r *rep) Edit(ctx context.Context, tenderID string, tender model.Tender) (model.Tender, error) {
+func (r *rep) TenderByID(ctx context.Context, tenderID string) (model.Tender, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf("SELECT %s FROM tender WHERE id = $1", tenderColumns)
+
+	repositoryTender, err := scanTender(r.db.QueryRow(ctx, stmt, tenderID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Tender{}, repository_tenders.ErrNoTenders
+		}
+
+		l.Error("Failed to get tender by id", "error", err.Error())
+		return model.Tender{}, repository_tenders.ErrInternal
+	}
+
+	return repository_tender_converter.ToTenderFromRepository(repositoryTender), nil
+}
+
+func (r *rep) Edit(ctx context.Context, tenderID string, tender model.Tender, expectedVersion *int) (model.Tender, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
 	sqlPatch := sql_patch.SQLPatches(tender)
 
+	//THE DECISION POLICY IS REPLACED AS A WHOLE, UNUSED PARAMETERS ARE CLEARED WITH NULL
+	if policy := tender.DecisionPolicy; policy != nil {
+		for _, field := range []struct {
+			column string
+			value  interface{}
+		}{
+			{"decision_policy", policy.Type},
+			{"required_approvals", policy.RequiredApprovals},
+			{"approver_id", policy.ApproverID},
+			{"rejection_veto", policy.RejectionVeto},
+		} {
+			sqlPatch.Args = append(sqlPatch.Args, field.value)
+			sqlPatch.Fields = append(sqlPatch.Fields, fmt.Sprintf("%s = $%d", field.column, len(sqlPatch.Args)))
+		}
+	}
+
 	if len(sqlPatch.Args) == 0 {
 		return model.Tender{}, repository_tenders.ErrNoSuggestionToUpdate
 	}
 
-	stmt := fmt.Sprintf(`UPDATE tender SET %s WHERE id = $1
-	                   RETURNING id, name, description, status, service_type, version, created_at`, strings.Join(sqlPatch.Fields, ", "))
+	args := append(sqlPatch.Args, tenderID)
+	condition := fmt.Sprintf("id = $%d", len(args))
+	if expectedVersion != nil {
+		args = append(args, *expectedVersion)
+		condition += fmt.Sprintf(" AND version = $%d", len(args))
+	}
 
-	repositoryTender := repository_tender_model.Tender{}
+	stmt := fmt.Sprintf(`UPDATE tender SET %s WHERE %s
+	                   RETURNING %s`, strings.Join(sqlPatch.Fields, ", "), condition, tenderColumns)
 
-	row := r.pool.QueryRow(ctx, stmt, append(sqlPatch.Args, tenderID))
-	if err := row.Scan(&repositoryTender.ID,
-		&repositoryTender.Name,
-		&repositoryTender.Description,
-		&repositoryTender.Status,
-		&repositoryTender.ServiceType,
-		&repositoryTender.Version,
-		&repositoryTender.CreatedAt); err != nil {
+	row := r.db.QueryRow(ctx, stmt, args...)
+	repositoryTender, err := scanTender(row)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			if expectedVersion != nil {
+				return model.Tender{}, r.versionMismatch(ctx, tenderID)
+			}
 			return model.Tender{}, repository_tenders.ErrNoTenders
 		}
 
@@ -272,7 +395,23 @@ func (r *rep) Edit(ctx context.Context, tenderID string, tender model.Tender) (m
 	return repository_tender_converter.ToTenderFromRepository(repositoryTender), nil
 }
 
-func (r *rep) RollbackVersion(ctx context.Context, tenderID string, version int) (model.Tender, error) {
+// versionMismatch tells a missing tender from one that has moved past the expected version.
+func (r *rep) versionMismatch(ctx context.Context, tenderID string) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM tender WHERE id = $1)", tenderID).Scan(&exists); err != nil {
+		l.Error("Failed to check tender existence", "error", err.Error())
+		return repository_tenders.ErrInternal
+	}
+
+	if !exists {
+		return repository_tenders.ErrNoTenders
+	}
+	return repository_tenders.ErrVersionConflict
+}
+
+func (r *rep) RollbackVersion(ctx context.Context, tenderID string, version int, changedBy string) (model.Tender, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
 	stmt := `UPDATE tender
@@ -282,20 +421,20 @@ func (r *rep) RollbackVersion(ctx context.Context, tenderID string, version int)
 			status = th.status,
 			organization_id = th.organization_id,
 			creator_username = th.creator_username,
-			version = th.version,
-			created_at = th.created_at
+			created_at = th.created_at,
+			decision_policy = th.decision_policy,
+			required_approvals = th.required_approvals,
+			approver_id = th.approver_id,
+			rejection_veto = th.rejection_veto,
+			changed_by = $3,
+			change_kind = 'Rollback'
 		FROM tender_history th
 		WHERE tender.id = th.id AND th.id = $1 AND th.version = $2
-		RETURNING th.id, th.name, th.description, th.status, th.service_type, th.version, th.created_at`
-
-	repositoryTender := repository_tender_model.Tender{}
-	if err := r.pool.QueryRow(ctx, stmt, tenderID, version).Scan(&repositoryTender.ID,
-		&repositoryTender.Name,
-		&repositoryTender.Description,
-		&repositoryTender.Status,
-		&repositoryTender.ServiceType,
-		&repositoryTender.Version,
-		&repositoryTender.CreatedAt); err != nil {
+		RETURNING tender.id, tender.name, tender.description, tender.status, tender.service_type, tender.version, tender.created_at,
+			tender.changed_by, tender.change_kind, tender.decision_policy, tender.required_approvals, tender.approver_id, tender.rejection_veto`
+
+	repositoryTender, err := scanTender(r.db.QueryRow(ctx, stmt, tenderID, version, changedBy))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.Tender{}, repository_tenders.ErrNoTenders
 		}
@@ -306,39 +445,95 @@ func (r *rep) RollbackVersion(ctx context.Context, tenderID string, version int)
 	return repository_tender_converter.ToTenderFromRepository(repositoryTender), nil
 }
 
-func (r *rep) ConfirmTenderCreator(ctx context.Context, tenderID string, userOrganizationID string) (exists bool, err error) {
+// tenderVersionsStmt lists the archived versions together with the current one. Versions written before
+// changed_at was recorded fall back to the archive time of their predecessor.
+const tenderVersionsStmt = `SELECT id, name, description, status, service_type, version, created_at, changed_by, change_kind,
+		decision_policy, required_approvals, approver_id, rejection_veto,
+		COALESCE(changed_at, LAG(archived_at) OVER (ORDER BY version), created_at) AS changed_at
+	FROM (
+		SELECT id, name, description, status, service_type, version, created_at, changed_by, change_kind,
+			decision_policy, required_approvals, approver_id, rejection_veto, changed_at, updated_at AS archived_at
+		FROM tender_history WHERE id = $1
+		UNION ALL
+		SELECT id, name, description, status, service_type, version, created_at, changed_by, change_kind,
+			decision_policy, required_approvals, approver_id, rejection_veto, changed_at, NULL
+		FROM tender WHERE id = $1
+	) versions`
+
+func scanTenderVersion(row pgx.Row) (version repository_tender_model.TenderVersion, err error) {
+	err = row.Scan(&version.ID,
+		&version.Name,
+		&version.Description,
+		&version.Status,
+		&version.ServiceType,
+		&version.Version,
+		&version.CreatedAt,
+		&version.ChangedBy,
+		&version.ChangeKind,
+		&version.DecisionPolicy,
+		&version.RequiredApprovals,
+		&version.ApproverID,
+		&version.RejectionVeto,
+		&version.ChangedAt)
+	return version, err
+}
+
+func (r *rep) Versions(ctx context.Context, tenderID string) ([]model.TenderVersion, error) {
 	l := logger.EndToEndLogging(ctx, r.logger)
 
-	stmt := "SELECT EXISTS(SELECT 1 FROM tender WHERE id = $1 and organization_id = $2)"
+	rows, err := r.db.Query(ctx, tenderVersionsStmt+" ORDER BY version", tenderID)
+	if err != nil {
+		l.Error("Failed to get tender versions", "error", err.Error())
+		return nil, repository_tenders.ErrInternal
+	}
+	defer rows.Close()
 
-	if err = r.pool.QueryRow(ctx, stmt, tenderID, userOrganizationID).Scan(&exists); err != nil {
-		l.Error("Failed to confirm tender creator", "error", err.Error())
-		return exists, repository_tenders.ErrInternal
+	versions := make([]model.TenderVersion, 0)
+
+	for rows.Next() {
+		version, err := scanTenderVersion(rows)
+		if err != nil {
+			l.Error("Failed to get tender versions", "error", err.Error())
+			return nil, repository_tenders.ErrInternal
+		}
+		versions = append(versions, repository_tender_converter.ToTenderVersionFromRepository(version))
 	}
 
-	return exists, nil
-}
+	if err = rows.Err(); err != nil {
+		l.Error("Failed to get tender versions", "error", err.Error())
+		return nil, repository_tenders.ErrInternal
+	}
+
+	if len(versions) == 0 {
+		return nil, repository_tenders.ErrNoTenders
+	}
 
-func (r *rep) CloseConn() {
-	r.pool.Close()
+	return versions, nil
 }
 
-func New(ctx context.Context, connStr string, logger *slog.Logger) (repository_tenders.Repository, error) {
-	pool, err := pgxpool.New(ctx, connStr)
+func (r *rep) Version(ctx context.Context, tenderID string, version int) (model.TenderVersion, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf("SELECT * FROM (%s) v WHERE version = $2", tenderVersionsStmt)
+
+	repositoryVersion, err := scanTenderVersion(r.db.QueryRow(ctx, stmt, tenderID, version))
 	if err != nil {
-		logger.Error("Failed to open connection to db", "error", err.Error())
-		return nil, repository.ErrOpenConn
-	}
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.TenderVersion{}, repository_tenders.ErrNoTenders
+		}
 
-	if err = pool.Ping(ctx); err != nil {
-		logger.Error("Failed to ping db", "error", err.Error())
-		return nil, repository.ErrPingDB
+		l.Error("Failed to get tender version", "error", err.Error())
+		return model.TenderVersion{}, repository_tenders.ErrInternal
 	}
 
+	return repository_tender_converter.ToTenderVersionFromRepository(repositoryVersion), nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_tenders.Repository {
 	r := &rep{
-		pool:   pool,
+		db:     db,
 		logger: logger,
 	}
 
-	return r, nil
+	return r
 }
@@ -6,15 +6,24 @@ import (
 )
 
 type Repository interface {
-	TenderOrganizationID(ctx context.Context, tenderID string) (organizationID string, err error)
-	TenderList(ctx context.Context, serviceTypes []string, limit int, offset int) ([]model.Tender, error)
+	//TenderList returns tenders matching the filter, ranked by relevance when a text query is given
+	TenderList(ctx context.Context, filter model.TenderFilter, page model.Page) ([]model.Tender, model.PageInfo, error)
 	Create(ctx context.Context, tender model.Tender) (model.Tender, error)
-	TendersByUser(ctx context.Context, username string, limit int, offset int) ([]model.Tender, error)
+	TendersByUser(ctx context.Context, username string, page model.Page) ([]model.Tender, model.PageInfo, error)
 	TenderStatus(ctx context.Context, tenderID string) (tenderOrganizationID string, status string, err error)
-	ChangeTenderStatusWithUserCheck(ctx context.Context, tenderID string, username string, status string) (model.Tender, error)
-	ChangeTenderStatusForce(ctx context.Context, tenderID string, status string) error
-	Edit(ctx context.Context, tenderID string, tender model.Tender) (model.Tender, error)
-	RollbackVersion(ctx context.Context, tenderID string, version int) (model.Tender, error)
-	ConfirmTenderCreator(ctx context.Context, tenderID string, userOrganizationID string) (exists bool, err error)
-	CloseConn()
+	//TenderStatusForUpdate locks the tender row until the surrounding transaction ends
+	TenderStatusForUpdate(ctx context.Context, tenderID string) (tenderOrganizationID string, status string, err error)
+	DecisionPolicy(ctx context.Context, tenderID string) (model.DecisionPolicy, error)
+	//ChangeTenderStatus does not check the caller, access is decided by the policy while the tender is locked
+	ChangeTenderStatus(ctx context.Context, tenderID string, status string, changedBy string) (model.Tender, error)
+	//ChangeTenderStatusForce attributes the change to no one when changedBy is empty
+	ChangeTenderStatusForce(ctx context.Context, tenderID string, status string, changedBy string) error
+	TenderByID(ctx context.Context, tenderID string) (model.Tender, error)
+	//Edit patches the tender. With expectedVersion set the patch applies only while the tender is still at that version
+	Edit(ctx context.Context, tenderID string, tender model.Tender, expectedVersion *int) (model.Tender, error)
+	//RollbackVersion restores the archived version as a new version attributed to changedBy
+	RollbackVersion(ctx context.Context, tenderID string, version int, changedBy string) (model.Tender, error)
+	//Versions returns every version of the tender, the current one included, oldest first
+	Versions(ctx context.Context, tenderID string) ([]model.TenderVersion, error)
+	Version(ctx context.Context, tenderID string, version int) (model.TenderVersion, error)
 }
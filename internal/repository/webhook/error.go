@@ -0,0 +1,9 @@
+package repository_webhook
+
+import "errors"
+
+var (
+	ErrInternal       = errors.New("internal error")
+	ErrNoSubscription = errors.New("no webhook subscription")
+	ErrNoDelivery     = errors.New("no webhook delivery")
+)
@@ -0,0 +1,439 @@
+package repository_webhook_postgres
+
+import (
+	"avito_intership/internal/model"
+	"avito_intership/internal/repository"
+	repository_webhook "avito_intership/internal/repository/webhook"
+	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+const subscriptionColumns = "id, organization_id, url, event_types, active, failure_count, disabled_at, created_at, updated_at"
+
+const deliveryColumns = `id, subscription_id, event_id, (SELECT event_type FROM outbox_event WHERE outbox_event.id = event_id),
+	status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at`
+
+type rep struct {
+	db     *postgres.DB
+	logger *slog.Logger
+}
+
+func scanSubscription(row pgx.Row) (model.WebhookSubscription, error) {
+	subscription := model.WebhookSubscription{}
+	err := row.Scan(&subscription.ID,
+		&subscription.OrganizationID,
+		&subscription.URL,
+		&subscription.EventTypes,
+		&subscription.Active,
+		&subscription.FailureCount,
+		&subscription.DisabledAt,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt)
+	return subscription, err
+}
+
+func scanDelivery(row pgx.Row) (model.WebhookDelivery, error) {
+	delivery := model.WebhookDelivery{}
+	err := row.Scan(&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.DeliveredAt)
+	return delivery, err
+}
+
+func (r *rep) Create(ctx context.Context, subscription model.WebhookSubscription) (model.WebhookSubscription, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf(`INSERT INTO webhook_subscription (organization_id, url, secret, event_types) VALUES ($1, $2, $3, $4)
+			 RETURNING %s`, subscriptionColumns)
+
+	created, err := scanSubscription(r.db.QueryRow(ctx, stmt, subscription.OrganizationID, subscription.URL, subscription.Secret, subscription.EventTypes))
+	if err != nil {
+		l.Error("Failed to create webhook subscription", "error", err.Error())
+		return model.WebhookSubscription{}, repository_webhook.ErrInternal
+	}
+	created.Secret = subscription.Secret
+
+	return created, nil
+}
+
+func (r *rep) Subscriptions(ctx context.Context, organizationIDs []string) ([]model.WebhookSubscription, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf("SELECT %s FROM webhook_subscription WHERE organization_id = ANY($1) ORDER BY created_at, id", subscriptionColumns)
+
+	rows, err := r.db.Query(ctx, stmt, organizationIDs)
+	if err != nil {
+		l.Error("Failed to get webhook subscriptions", "error", err.Error())
+		return nil, repository_webhook.ErrInternal
+	}
+	defer rows.Close()
+
+	subscriptions := make([]model.WebhookSubscription, 0)
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			l.Error("Failed to scan webhook subscription", "error", err.Error())
+			return nil, repository_webhook.ErrInternal
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Error("Failed to get webhook subscriptions", "error", err.Error())
+		return nil, repository_webhook.ErrInternal
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, repository_webhook.ErrNoSubscription
+	}
+
+	return subscriptions, nil
+}
+
+func (r *rep) Subscription(ctx context.Context, organizationIDs []string, subscriptionID string) (model.WebhookSubscription, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf("SELECT %s FROM webhook_subscription WHERE id = $1 AND organization_id = ANY($2)", subscriptionColumns)
+
+	subscription, err := scanSubscription(r.db.QueryRow(ctx, stmt, subscriptionID, organizationIDs))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.WebhookSubscription{}, repository_webhook.ErrNoSubscription
+		}
+
+		l.Error("Failed to get webhook subscription", "error", err.Error())
+		return model.WebhookSubscription{}, repository_webhook.ErrInternal
+	}
+
+	return subscription, nil
+}
+
+func (r *rep) Update(ctx context.Context, organizationIDs []string, subscriptionID string, patch model.WebhookSubscriptionPatch) (model.WebhookSubscription, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	args := []interface{}{subscriptionID, organizationIDs}
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	fields := []string{"updated_at = CURRENT_TIMESTAMP"}
+	if patch.URL != nil {
+		fields = append(fields, "url = "+placeholder(*patch.URL))
+	}
+	if patch.EventTypes != nil {
+		fields = append(fields, "event_types = "+placeholder(*patch.EventTypes))
+	}
+	if patch.Active != nil {
+		fields = append(fields, "active = "+placeholder(*patch.Active))
+		//A REACTIVATED ENDPOINT STARTS OVER, ITS PAST FAILURES MUST NOT DISABLE IT AGAIN AT ONCE
+		if *patch.Active {
+			fields = append(fields, "failure_count = 0", "disabled_at = NULL")
+		}
+	}
+
+	stmt := fmt.Sprintf("UPDATE webhook_subscription SET %s WHERE id = $1 AND organization_id = ANY($2) RETURNING %s",
+		strings.Join(fields, ", "), subscriptionColumns)
+
+	subscription, err := scanSubscription(r.db.QueryRow(ctx, stmt, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.WebhookSubscription{}, repository_webhook.ErrNoSubscription
+		}
+
+		l.Error("Failed to update webhook subscription", "error", err.Error())
+		return model.WebhookSubscription{}, repository_webhook.ErrInternal
+	}
+
+	return subscription, nil
+}
+
+func (r *rep) Delete(ctx context.Context, organizationIDs []string, subscriptionID string) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := "DELETE FROM webhook_subscription WHERE id = $1 AND organization_id = ANY($2)"
+
+	tag, err := r.db.Exec(ctx, stmt, subscriptionID, organizationIDs)
+	if err != nil {
+		l.Error("Failed to delete webhook subscription", "error", err.Error())
+		return repository_webhook.ErrInternal
+	}
+
+	if tag.RowsAffected() == 0 {
+		return repository_webhook.ErrNoSubscription
+	}
+
+	return nil
+}
+
+func (r *rep) RotateSecret(ctx context.Context, organizationIDs []string, subscriptionID, secret string) (model.WebhookSubscription, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf(`UPDATE webhook_subscription SET secret = $3, updated_at = CURRENT_TIMESTAMP
+			 WHERE id = $1 AND organization_id = ANY($2) RETURNING %s`, subscriptionColumns)
+
+	subscription, err := scanSubscription(r.db.QueryRow(ctx, stmt, subscriptionID, organizationIDs, secret))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.WebhookSubscription{}, repository_webhook.ErrNoSubscription
+		}
+
+		l.Error("Failed to rotate webhook secret", "error", err.Error())
+		return model.WebhookSubscription{}, repository_webhook.ErrInternal
+	}
+	subscription.Secret = secret
+
+	return subscription, nil
+}
+
+func (r *rep) Enqueue(ctx context.Context, event model.Event) (int, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	//THE UNIQUE KEY KEEPS A REDELIVERED EVENT FROM BEING QUEUED TWICE FOR THE SAME SUBSCRIPTION
+	stmt := `INSERT INTO webhook_delivery (subscription_id, event_id)
+			 SELECT id, $1 FROM webhook_subscription
+			 WHERE active AND organization_id = ANY($2) AND (cardinality(event_types) = 0 OR $3 = ANY(event_types))
+			 ON CONFLICT (subscription_id, event_id) DO NOTHING`
+
+	tag, err := r.db.Exec(ctx, stmt, event.ID, event.OrganizationIDs, event.Type)
+	if err != nil {
+		l.Error("Failed to enqueue webhook deliveries", "error", err.Error())
+		return 0, repository_webhook.ErrInternal
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *rep) Deliveries(ctx context.Context, subscriptionID string, status string, page model.Page) ([]model.WebhookDelivery, model.PageInfo, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	args := make([]interface{}, 0)
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{fmt.Sprintf("subscription_id = %s", placeholder(subscriptionID))}
+	if status != "" {
+		conditions = append(conditions, fmt.Sprintf("status::text = %s", placeholder(status)))
+	}
+
+	pageInfo := model.PageInfo{}
+	if page.WithTotal {
+		total, err := repository.Total(ctx, r.db, "webhook_delivery", repository.Where(conditions...), args)
+		if err != nil {
+			l.Error("Failed to count webhook deliveries", "error", err.Error())
+			return nil, model.PageInfo{}, repository_webhook.ErrInternal
+		}
+		pageInfo.Total = total
+	}
+
+	conditions = append(conditions, repository.KeysetCondition(page, placeholder))
+
+	stmt := fmt.Sprintf("SELECT %s FROM webhook_delivery %s ORDER BY created_at DESC, id DESC %s",
+		deliveryColumns, repository.Where(conditions...), repository.LimitOffset(page, placeholder))
+
+	rows, err := r.db.Query(ctx, stmt, args...)
+	if err != nil {
+		l.Error("Failed to get webhook deliveries", "error", err.Error())
+		return nil, model.PageInfo{}, repository_webhook.ErrInternal
+	}
+	defer rows.Close()
+
+	deliveries := make([]model.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			l.Error("Failed to scan webhook delivery", "error", err.Error())
+			return nil, model.PageInfo{}, repository_webhook.ErrInternal
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Error("Failed to get webhook deliveries", "error", err.Error())
+		return nil, model.PageInfo{}, repository_webhook.ErrInternal
+	}
+
+	if len(deliveries) == 0 {
+		return nil, model.PageInfo{}, repository_webhook.ErrNoDelivery
+	}
+
+	deliveries, pageInfo.NextCursor = repository.NextPage(deliveries, page, deliveryCursor)
+
+	return deliveries, pageInfo, nil
+}
+
+func deliveryCursor(delivery model.WebhookDelivery) model.Cursor {
+	return model.Cursor{CreatedAt: delivery.CreatedAt, ID: delivery.ID}
+}
+
+func (r *rep) Replay(ctx context.Context, subscriptionID, deliveryID string) (model.WebhookDelivery, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := fmt.Sprintf(`UPDATE webhook_delivery SET status = 'Pending', attempts = 0, next_attempt_at = CURRENT_TIMESTAMP
+			 WHERE id = $1 AND subscription_id = $2 RETURNING %s`, deliveryColumns)
+
+	delivery, err := scanDelivery(r.db.QueryRow(ctx, stmt, deliveryID, subscriptionID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.WebhookDelivery{}, repository_webhook.ErrNoDelivery
+		}
+
+		l.Error("Failed to replay webhook delivery", "error", err.Error())
+		return model.WebhookDelivery{}, repository_webhook.ErrInternal
+	}
+
+	return delivery, nil
+}
+
+func (r *rep) Claim(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDispatch, error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := `UPDATE webhook_delivery d SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+			 FROM webhook_subscription s, outbox_event e
+			 WHERE d.id IN (
+			     SELECT wd.id FROM webhook_delivery wd
+			     JOIN webhook_subscription ws ON ws.id = wd.subscription_id
+			     WHERE wd.status = 'Pending' AND wd.next_attempt_at <= CURRENT_TIMESTAMP AND ws.active
+			     ORDER BY wd.created_at
+			     LIMIT $1
+			     FOR UPDATE OF wd SKIP LOCKED)
+			   AND s.id = d.subscription_id AND e.id = d.event_id
+			 RETURNING d.id, d.subscription_id, d.event_id, d.status, d.attempts, d.created_at, s.url, s.secret,
+			     e.event_type, e.aggregate_type, e.aggregate_id, e.payload, e.created_at`
+
+	rows, err := r.db.Query(ctx, stmt, limit, lease.Seconds())
+	if err != nil {
+		l.Error("Failed to claim webhook deliveries", "error", err.Error())
+		return nil, repository_webhook.ErrInternal
+	}
+	defer rows.Close()
+
+	dispatches := make([]model.WebhookDispatch, 0)
+	for rows.Next() {
+		dispatch := model.WebhookDispatch{}
+		if err = rows.Scan(&dispatch.Delivery.ID,
+			&dispatch.Delivery.SubscriptionID,
+			&dispatch.Delivery.EventID,
+			&dispatch.Delivery.Status,
+			&dispatch.Delivery.Attempts,
+			&dispatch.Delivery.CreatedAt,
+			&dispatch.URL,
+			&dispatch.Secret,
+			&dispatch.Event.Type,
+			&dispatch.Event.AggregateType,
+			&dispatch.Event.AggregateID,
+			&dispatch.Event.Payload,
+			&dispatch.Event.CreatedAt); err != nil {
+			l.Error("Failed to scan webhook delivery", "error", err.Error())
+			return nil, repository_webhook.ErrInternal
+		}
+		dispatch.Event.ID = dispatch.Delivery.EventID
+		dispatch.Delivery.EventType = dispatch.Event.Type
+		dispatches = append(dispatches, dispatch)
+	}
+
+	if err = rows.Err(); err != nil {
+		l.Error("Failed to claim webhook deliveries", "error", err.Error())
+		return nil, repository_webhook.ErrInternal
+	}
+
+	//RETURNING KEEPS NO ORDER, EACH ENDPOINT GETS ITS EVENTS IN THE ORDER THEY HAPPENED
+	slices.SortFunc(dispatches, func(a, b model.WebhookDispatch) int {
+		return a.Event.CreatedAt.Compare(b.Event.CreatedAt)
+	})
+
+	return dispatches, nil
+}
+
+func (r *rep) MarkDelivered(ctx context.Context, deliveryID string, statusCode int) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := `UPDATE webhook_delivery
+			 SET status = 'Delivered', attempts = attempts + 1, last_status_code = $2, last_error = NULL, delivered_at = CURRENT_TIMESTAMP
+			 WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, stmt, deliveryID, statusCode); err != nil {
+		l.Error("Failed to mark webhook delivery delivered", "error", err.Error())
+		return repository_webhook.ErrInternal
+	}
+
+	return nil
+}
+
+func (r *rep) MarkFailed(ctx context.Context, deliveryID string, statusCode *int, lastError string, retryIn time.Duration, giveUp bool) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := `UPDATE webhook_delivery
+			 SET status = CASE WHEN $5 THEN 'Failed'::webhook_delivery_status ELSE status END,
+			     attempts = attempts + 1, last_status_code = $2, last_error = $3,
+			     next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $4)
+			 WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, stmt, deliveryID, statusCode, lastError, retryIn.Seconds(), giveUp); err != nil {
+		l.Error("Failed to mark webhook delivery failed", "error", err.Error())
+		return repository_webhook.ErrInternal
+	}
+
+	return nil
+}
+
+func (r *rep) ResetFailures(ctx context.Context, subscriptionID string) error {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := "UPDATE webhook_subscription SET failure_count = 0 WHERE id = $1 AND failure_count <> 0"
+
+	if _, err := r.db.Exec(ctx, stmt, subscriptionID); err != nil {
+		l.Error("Failed to reset webhook failures", "error", err.Error())
+		return repository_webhook.ErrInternal
+	}
+
+	return nil
+}
+
+func (r *rep) CountFailure(ctx context.Context, subscriptionID string, disableAfter int) (disabled bool, err error) {
+	l := logger.EndToEndLogging(ctx, r.logger)
+
+	stmt := `UPDATE webhook_subscription
+			 SET failure_count = failure_count + 1,
+			     active = active AND failure_count + 1 < $2,
+			     disabled_at = CASE WHEN active AND failure_count + 1 >= $2 THEN CURRENT_TIMESTAMP ELSE disabled_at END
+			 WHERE id = $1
+			 RETURNING NOT active`
+
+	if err = r.db.QueryRow(ctx, stmt, subscriptionID, disableAfter).Scan(&disabled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, repository_webhook.ErrNoSubscription
+		}
+
+		l.Error("Failed to count webhook failure", "error", err.Error())
+		return false, repository_webhook.ErrInternal
+	}
+
+	return disabled, nil
+}
+
+func New(db *postgres.DB, logger *slog.Logger) repository_webhook.Repository {
+	return &rep{
+		db:     db,
+		logger: logger,
+	}
+}
@@ -0,0 +1,32 @@
+package repository_webhook
+
+import (
+	"avito_intership/internal/model"
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, subscription model.WebhookSubscription) (model.WebhookSubscription, error)
+	//Subscriptions and the calls on a single subscription below are scoped to the given organizations
+	Subscriptions(ctx context.Context, organizationIDs []string) ([]model.WebhookSubscription, error)
+	Subscription(ctx context.Context, organizationIDs []string, subscriptionID string) (model.WebhookSubscription, error)
+	//Update applies the patch. Reactivating a subscription clears its failure count
+	Update(ctx context.Context, organizationIDs []string, subscriptionID string, patch model.WebhookSubscriptionPatch) (model.WebhookSubscription, error)
+	Delete(ctx context.Context, organizationIDs []string, subscriptionID string) error
+	RotateSecret(ctx context.Context, organizationIDs []string, subscriptionID, secret string) (model.WebhookSubscription, error)
+
+	//Enqueue creates a pending delivery for every active subscription of the event organizations that accepts its type
+	Enqueue(ctx context.Context, event model.Event) (int, error)
+	Deliveries(ctx context.Context, subscriptionID string, status string, page model.Page) ([]model.WebhookDelivery, model.PageInfo, error)
+	//Replay makes the delivery pending again with a fresh set of attempts
+	Replay(ctx context.Context, subscriptionID, deliveryID string) (model.WebhookDelivery, error)
+	//Claim leases up to limit due deliveries of active subscriptions for the lease duration
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDispatch, error)
+	MarkDelivered(ctx context.Context, deliveryID string, statusCode int) error
+	//MarkFailed counts the attempt. The delivery is retried after retryIn, or given up when giveUp is set
+	MarkFailed(ctx context.Context, deliveryID string, statusCode *int, lastError string, retryIn time.Duration, giveUp bool) error
+	ResetFailures(ctx context.Context, subscriptionID string) error
+	//CountFailure increments the consecutive failures of the subscription and deactivates it once they reach disableAfter
+	CountFailure(ctx context.Context, subscriptionID string, disableAfter int) (disabled bool, err error)
+}
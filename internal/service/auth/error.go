@@ -0,0 +1,9 @@
+package service_auth
+
+import "errors"
+
+var (
+	ErrInternal           = errors.New("internal error")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidToken       = errors.New("invalid token")
+)
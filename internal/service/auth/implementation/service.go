@@ -0,0 +1,90 @@
+package service_auth_impl
+
+import (
+	"avito_intership/internal/auth"
+	service_auth "avito_intership/internal/service/auth"
+	service_employee "avito_intership/internal/service/employee"
+	service_organization_resp "avito_intership/internal/service/organization_responsible"
+	"context"
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+)
+
+type service struct {
+	tokenManager *auth.TokenManager
+
+	employeeService         service_employee.Service
+	organizationRespService service_organization_resp.Service
+
+	logger *slog.Logger
+}
+
+func (s *service) Login(ctx context.Context, username string, password string) (token string, err error) {
+	userID, passwordHash, err := s.employeeService.CredentialsByUsername(ctx, username)
+	if err != nil {
+		switch {
+		case errors.Is(err, service_employee.ErrNonExistingEmployee):
+			return "", service_auth.ErrInvalidCredentials
+		default:
+			return "", service_auth.ErrInternal
+		}
+	}
+
+	//EMPLOYEES WITHOUT A PASSWORD CAN NOT LOG IN
+	if passwordHash == "" {
+		return "", service_auth.ErrInvalidCredentials
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)); err != nil {
+		return "", service_auth.ErrInvalidCredentials
+	}
+
+	token, err = s.tokenManager.Issue(userID)
+	if err != nil {
+		s.logger.Error("Failed to issue token", "error", err.Error())
+		return "", service_auth.ErrInternal
+	}
+
+	return token, nil
+}
+
+func (s *service) Authenticate(ctx context.Context, token string) (auth.Identity, error) {
+	userID, err := s.tokenManager.Parse(token)
+	if err != nil {
+		return auth.Identity{}, service_auth.ErrInvalidToken
+	}
+
+	//THE EMPLOYEE COULD HAVE BEEN REMOVED AFTER THE TOKEN WAS ISSUED
+	username, err := s.employeeService.UsernameByID(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service_employee.ErrNonExistingEmployee):
+			return auth.Identity{}, service_auth.ErrInvalidToken
+		default:
+			return auth.Identity{}, service_auth.ErrInternal
+		}
+	}
+
+	organizationIDs, err := s.organizationRespService.OrganizationIDsByRepresentative(ctx, userID)
+	if err != nil && !errors.Is(err, service_organization_resp.ErrUserHasNoOrganization) {
+		return auth.Identity{}, service_auth.ErrInternal
+	}
+
+	return auth.Identity{
+		EmployeeID:      userID,
+		Username:        username,
+		OrganizationIDs: organizationIDs,
+	}, nil
+}
+
+func New(tokenManager *auth.TokenManager, employeeService service_employee.Service, organizationRespService service_organization_resp.Service, logger *slog.Logger) service_auth.Service {
+	s := &service{
+		tokenManager:            tokenManager,
+		employeeService:         employeeService,
+		organizationRespService: organizationRespService,
+		logger:                  logger,
+	}
+
+	return s
+}
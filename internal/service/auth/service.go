@@ -0,0 +1,13 @@
+package service_auth
+
+import (
+	"avito_intership/internal/auth"
+	"context"
+)
+
+type Service interface {
+	//Login checks employee credentials and issues a bearer token
+	Login(ctx context.Context, username string, password string) (token string, err error)
+	//Authenticate resolves a bearer token into the caller identity
+	Authenticate(ctx context.Context, token string) (auth.Identity, error)
+}
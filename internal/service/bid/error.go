@@ -10,9 +10,14 @@ var (
 	ErrInternal             = errors.New("internal error")
 	ErrNoBids               = errors.New("no bid")
 	ErrForbidden            = errors.New("forbidden")
+	ErrUnauthorized         = errors.New("unauthorized")
 	ErrInvalidBidStatus     = errors.New("invalid bid status")
 	ErrNoSuggestionToUpdate = errors.New("no suggestion to update")
 	ErrTenderClosed         = errors.New("tender has been closed")
+	ErrTenderNotPublished   = errors.New("tender is not published")
+
+	ErrInvalidStatusTransition = errors.New("status transition is not allowed")
+	ErrVersionConflict         = errors.New("bid has been changed since the expected version")
 
 	ErrBidBeenRejected = errors.New("bid been rejected")
 )
@@ -1,129 +1,202 @@
 package service_bids_impl
 
 import (
+	"avito_intership/internal/auth"
+	"avito_intership/internal/authz"
+	"avito_intership/internal/metrics"
 	"avito_intership/internal/model"
+	"avito_intership/internal/quorum"
 	repository_bid "avito_intership/internal/repository/bid"
 	service_bids "avito_intership/internal/service/bid"
 	service_decision "avito_intership/internal/service/decision"
 	service_employee "avito_intership/internal/service/employee"
 	service_feedback "avito_intership/internal/service/feedback"
 	service_organization_resp "avito_intership/internal/service/organization_responsible"
+	service_outbox "avito_intership/internal/service/outbox"
 	service_tenders "avito_intership/internal/service/tender"
+	"avito_intership/pkg/logger"
+	"avito_intership/pkg/postgres"
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
 )
 
 type service struct {
 	bidsRepository repository_bid.Repository
+	txManager      postgres.TxManager
+	policy         authz.Policy
 
 	employeeService         service_employee.Service
 	organizationRespService service_organization_resp.Service
 	tenderService           service_tenders.Service
 	decisionService         service_decision.Service
 	feedbackService         service_feedback.Service
+	outboxService           service_outbox.Service
+
+	decisionRules *quorum.Registry
 
 	logger *slog.Logger
 }
 
 var (
-	tenderClosedStatus = "Closed"
+	tenderClosedStatus    = "Closed"
+	tenderPublishedStatus = "Published"
+
+	bidStatuses = []string{"Created", "Published", "Canceled"}
+
+	//bidTransitions lists the statuses a bid may move to from its current status. Canceled is final
+	bidTransitions = map[string][]string{
+		"Created":   {"Published", "Canceled"},
+		"Published": {"Canceled"},
+	}
 )
 
-func (s *service) organizationIDAndUserIDByUsername(ctx context.Context, username string) (userID string, organizationID string, err error) {
-	userID, err = s.employeeService.IDByUsername(ctx, username)
-	if err != nil {
-		return "", "", err
+// accessError translates an authorization failure. notFound is returned for resources hidden from the caller
+func accessError(err error, notFound error) error {
+	switch {
+	case errors.Is(err, authz.ErrUnauthenticated):
+		return service_bids.ErrUnauthorized
+	case errors.Is(err, authz.ErrForbidden):
+		return service_bids.ErrForbidden
+	case errors.Is(err, authz.ErrNotFound):
+		return notFound
+	case errors.Is(err, authz.ErrNotOpen):
+		return service_bids.ErrInvalidStatusTransition
+	default:
+		return service_bids.ErrInternal
 	}
+}
 
-	organizationID, err = s.organizationRespService.GetOrganizationIDByRepresentative(ctx, userID)
-	if err != nil {
-		return "", "", err
+// audience lists the organizations an event on the bid is about: the tender owner and the authoring organization
+func audience(bid model.Bid, tenderOrganizationID string) []string {
+	organizationIDs := []string{tenderOrganizationID}
+	if *bid.AuthorType == model.BidAuthorOrganization && *bid.AuthorID != tenderOrganizationID {
+		organizationIDs = append(organizationIDs, *bid.AuthorID)
 	}
+	return organizationIDs
+}
 
-	return userID, organizationID, nil
+// publishSubmitted records bid.submitted once the bid is published to the tender owner. It must run in the transaction of the change
+func (s *service) publishSubmitted(ctx context.Context, bid model.Bid, tenderID, tenderOrganizationID string) error {
+	payload := model.BidEventPayload{
+		BidID:      *bid.ID,
+		TenderID:   tenderID,
+		AuthorType: *bid.AuthorType,
+		AuthorID:   *bid.AuthorID,
+		Status:     *bid.Status,
+		Version:    *bid.Version,
+	}
+
+	if err := s.outboxService.Publish(ctx, model.EventBidSubmitted, model.AggregateBid, *bid.ID, audience(bid, tenderOrganizationID), payload); err != nil {
+		return service_bids.ErrInternal
+	}
+	return nil
 }
 
 func (s *service) Create(ctx context.Context, bid model.Bid) (model.Bid, error) {
-	bid, err := s.bidsRepository.Create(ctx, bid)
-	if err != nil {
-		switch {
-		case errors.Is(err, repository_bid.ErrInvalidReq):
-			return model.Bid{}, service_bids.ErrInvalidReq
-		case errors.Is(err, repository_bid.ErrInvalidAuthorID):
-			return model.Bid{}, service_bids.ErrInvalidAuthorID
-		case errors.Is(err, repository_bid.ErrInvalidTenderID):
-			return model.Bid{}, service_bids.ErrInvalidTenderID
-		default:
-			return model.Bid{}, service_bids.ErrInternal
+	if _, ok := auth.IdentityFromContext(ctx); !ok {
+		return model.Bid{}, service_bids.ErrUnauthorized
+	}
+
+	if bid.AuthorType == nil || bid.AuthorID == nil || bid.TenderID == nil {
+		return model.Bid{}, service_bids.ErrInvalidReq
+	}
+
+	//BIDS ARE CREATED ON BEHALF OF THE CALLER OR THE CALLER ORGANIZATION ONLY
+	if err := s.policy.CanBidAs(ctx, *bid.AuthorType, *bid.AuthorID); err != nil {
+		return model.Bid{}, accessError(err, service_bids.ErrInvalidAuthorID)
+	}
+
+	identity, _ := auth.IdentityFromContext(ctx)
+	bid.ChangedBy = &identity.EmployeeID
+
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		//LOCK TENDER. BIDS ARE ACCEPTED ONLY WHILE THE TENDER IS PUBLISHED
+		_, tenderStatus, txErr := s.tenderService.TenderStatusForUpdate(ctx, *bid.TenderID)
+		if txErr != nil {
+			if errors.Is(txErr, service_tenders.ErrNoTenders) {
+				return service_bids.ErrInvalidTenderID
+			}
+			return txErr
 		}
+
+		if tenderStatus != tenderPublishedStatus {
+			return service_bids.ErrTenderNotPublished
+		}
+
+		bid, txErr = s.bidsRepository.Create(ctx, bid)
+		if txErr != nil {
+			switch {
+			case errors.Is(txErr, repository_bid.ErrInvalidReq):
+				return service_bids.ErrInvalidReq
+			case errors.Is(txErr, repository_bid.ErrInvalidAuthorID):
+				return service_bids.ErrInvalidAuthorID
+			case errors.Is(txErr, repository_bid.ErrInvalidTenderID):
+				return service_bids.ErrInvalidTenderID
+			default:
+				return service_bids.ErrInternal
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.Bid{}, err
 	}
+	metrics.BidsCreated.Inc()
 
 	return bid, nil
 }
 
-func (s *service) BidsByUser(ctx context.Context, username string, limit int, offset int) ([]model.Bid, error) {
-	userID, organizationID, err := s.organizationIDAndUserIDByUsername(ctx, username)
-	if err != nil {
-		if !errors.Is(err, service_organization_resp.ErrUserHasNoOrganization) {
-			return nil, err
-		}
+func (s *service) BidsByUser(ctx context.Context, page model.Page) ([]model.Bid, model.PageInfo, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, model.PageInfo{}, service_bids.ErrUnauthorized
 	}
 
-	bids, err := s.bidsRepository.BidsByAuthorID(ctx, userID, organizationID, limit, offset)
+	//A USER WITHOUT ORGANIZATION HAS ITS OWN BIDS ONLY
+	bids, pageInfo, err := s.bidsRepository.BidsByAuthorID(ctx, identity.EmployeeID, identity.OrganizationIDs, page)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository_bid.ErrNoBids):
-			return nil, service_bids.ErrNoBids
+			return nil, model.PageInfo{}, service_bids.ErrNoBids
 		default:
-			return nil, service_bids.ErrInternal
+			return nil, model.PageInfo{}, service_bids.ErrInternal
 		}
 	}
 
-	return bids, nil
+	return bids, pageInfo, nil
 }
 
-func (s *service) BidsByTenderID(ctx context.Context, tenderID string, username string, limit int, offset int) ([]model.Bid, error) {
-	//CHECK USER. ONLY TENDER CREATOR CAN USE THIS FUNCTIONAL
-	_, organizationID, err := s.organizationIDAndUserIDByUsername(ctx, username)
-	if err != nil {
-		if errors.Is(err, service_organization_resp.ErrUserHasNoOrganization) {
-			return nil, service_bids.ErrForbidden
-		}
+func (s *service) BidsByTenderID(ctx context.Context, tenderID string, page model.Page) ([]model.Bid, model.PageInfo, error) {
+	//CHECK ACCESS. ONLY THE ORGANIZATION THAT LAUNCHED THE TENDER READS ITS BIDS
+	if err := s.policy.CanManageTender(ctx, tenderID); err != nil {
+		return nil, model.PageInfo{}, accessError(err, service_tenders.ErrNoTenders)
 	}
 
-	//CHECK THAT USER ORGANIZATION IS A TENDER CREATOR
-	exists, err := s.tenderService.ConfirmTenderCreator(ctx, tenderID, organizationID)
-	if err != nil {
-		return nil, err
-	}
-
-	if !exists {
-		return nil, service_bids.ErrForbidden
-	}
-
-	bids, err := s.bidsRepository.BidsByTenderID(ctx, tenderID, limit, offset)
+	//DRAFTS STAY HIDDEN FROM THE TENDER OWNER UNLESS IT AUTHORED THEM
+	identity, _ := auth.IdentityFromContext(ctx)
+	bids, pageInfo, err := s.bidsRepository.BidsByTenderID(ctx, tenderID, identity.EmployeeID, identity.OrganizationIDs, page)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository_bid.ErrNoBids):
-			return nil, service_bids.ErrNoBids
+			return nil, model.PageInfo{}, service_bids.ErrNoBids
 		default:
-			return nil, service_bids.ErrInternal
+			return nil, model.PageInfo{}, service_bids.ErrInternal
 		}
 	}
 
-	return bids, nil
+	return bids, pageInfo, nil
 }
 
-func (s *service) GetStatus(ctx context.Context, bidID string, username string) (status string, err error) {
-	userID, organizationID, err := s.organizationIDAndUserIDByUsername(ctx, username)
-	if err != nil {
-		if !errors.Is(err, service_organization_resp.ErrUserHasNoOrganization) {
-			return "", err
-		}
+func (s *service) GetStatus(ctx context.Context, bidID string) (status string, err error) {
+	//CHECK ACCESS
+	if err = s.policy.CanViewBid(ctx, bidID); err != nil {
+		return "", accessError(err, service_bids.ErrNoBids)
 	}
 
-	status, tenderID, authorID, err := s.bidsRepository.GetStatus(ctx, bidID)
+	status, _, _, err = s.bidsRepository.GetStatus(ctx, bidID)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository_bid.ErrNoBids):
@@ -133,121 +206,279 @@ func (s *service) GetStatus(ctx context.Context, bidID string, username string)
 		}
 	}
 
-	tenderOrganizationID, err := s.tenderService.TenderOrganizationID(ctx, tenderID)
-	if err != nil {
-		return "", err
+	return status, nil
+}
+
+func (s *service) ChangeStatus(ctx context.Context, bidID string, status string) (bid model.Bid, err error) {
+	//CHECK ACCESS
+	if err := s.policy.CanEditBid(ctx, bidID); err != nil {
+		return model.Bid{}, accessError(err, service_bids.ErrNoBids)
 	}
 
-	if userID != authorID && organizationID != authorID && organizationID != tenderOrganizationID {
-		return "", service_bids.ErrForbidden
+	identity, _ := auth.IdentityFromContext(ctx)
+	userID := identity.EmployeeID
+
+	if !slices.Contains(bidStatuses, status) {
+		return model.Bid{}, service_bids.ErrInvalidBidStatus
 	}
 
-	return status, nil
+	err = s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		currentStatus, tenderID, _, txErr := s.bidsRepository.GetStatus(ctx, bidID)
+		if txErr != nil {
+			switch {
+			case errors.Is(txErr, repository_bid.ErrNoBids):
+				return service_bids.ErrNoBids
+			default:
+				return service_bids.ErrInternal
+			}
+		}
+
+		tenderOrganizationID, txErr := s.lockOpenTender(ctx, tenderID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if !slices.Contains(bidTransitions[currentStatus], status) {
+			return service_bids.ErrInvalidStatusTransition
+		}
+
+		//CHANGE STATUS
+		bid, txErr = s.bidsRepository.ChangeStatus(ctx, bidID, status, userID)
+		if txErr != nil {
+			switch {
+			case errors.Is(txErr, repository_bid.ErrInvalidBidStatus):
+				return service_bids.ErrInvalidBidStatus
+			case errors.Is(txErr, repository_bid.ErrNoBids):
+				return service_bids.ErrNoBids
+			default:
+				return service_bids.ErrInternal
+			}
+		}
+
+		if status != model.BidStatusPublished {
+			return nil
+		}
+		return s.publishSubmitted(ctx, bid, tenderID, tenderOrganizationID)
+	})
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	return bid, nil
 }
 
-func (s *service) ChangeStatus(ctx context.Context, bidID string, username string, status string) (bid model.Bid, err error) {
+func (s *service) Edit(ctx context.Context, bidID string, bid model.Bid, expectedVersion *int) (model.Bid, error) {
 	//CHECK ACCESS
-	userID, organizationID, err := s.organizationIDAndUserIDByUsername(ctx, username)
+	if err := s.policy.CanEditBid(ctx, bidID); err != nil {
+		return model.Bid{}, accessError(err, service_bids.ErrNoBids)
+	}
+
+	identity, _ := auth.IdentityFromContext(ctx)
+	userID := identity.EmployeeID
+
+	//EDIT. ONLY THE BID CONTENT IS PATCHED, AUTHORSHIP AND HISTORY FIELDS ARE SET HERE
+	changeKind := model.ChangeKindEdit
+	patch := model.Bid{
+		Name:        bid.Name,
+		Description: bid.Description,
+	}
+	if patch == (model.Bid{}) {
+		return model.Bid{}, service_bids.ErrNoSuggestionToUpdate
+	}
+	patch.ChangedBy = &userID
+	patch.ChangeKind = &changeKind
+
+	var updatedBid model.Bid
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		tenderID, txErr := s.bidsRepository.BidTenderID(ctx, bidID)
+		if txErr != nil {
+			return service_bids.ErrInternal
+		}
+
+		if _, txErr = s.lockOpenTender(ctx, tenderID); txErr != nil {
+			return txErr
+		}
+
+		updatedBid, txErr = s.bidsRepository.Edit(ctx, bidID, patch, expectedVersion)
+		if txErr != nil {
+			switch {
+			case errors.Is(txErr, repository_bid.ErrNoSuggestionToUpdate):
+				return service_bids.ErrNoSuggestionToUpdate
+			case errors.Is(txErr, repository_bid.ErrNoBids):
+				return service_bids.ErrNoBids
+			case errors.Is(txErr, repository_bid.ErrVersionConflict):
+				//THE CALLER GETS THE CURRENT STATE TO REAPPLY ITS CHANGES ON
+				if updatedBid, txErr = s.bidsRepository.BidByID(ctx, bidID); txErr != nil {
+					return service_bids.ErrInternal
+				}
+				return service_bids.ErrVersionConflict
+			default:
+				return service_bids.ErrInternal
+			}
+		}
+
+		return nil
+	})
+	if errors.Is(err, service_bids.ErrVersionConflict) {
+		return updatedBid, err
+	}
 	if err != nil {
 		return model.Bid{}, err
 	}
 
-	authorID, err := s.bidsRepository.BidAuthorID(ctx, bidID)
+	return updatedBid, nil
+}
+
+func (s *service) SubmitDecision(ctx context.Context, bidID string, decision string) (bid model.Bid, isWinner bool, err error) {
+	err = s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		var txErr error
+		bid, isWinner, txErr = s.submitDecision(ctx, bidID, decision)
+		return txErr
+	})
 	if err != nil {
-		return model.Bid{}, service_bids.ErrInternal
+		return model.Bid{}, false, err
 	}
 
-	if authorID != userID && authorID != organizationID {
-		return model.Bid{}, service_bids.ErrForbidden
+	//COUNT ONLY COMMITTED DECISIONS
+	metrics.DecisionsSubmitted.WithLabelValues(decision).Inc()
+	if isWinner {
+		metrics.TendersClosedByQuorum.Inc()
 	}
 
-	//CHANGE STATUS
-	bid, err = s.bidsRepository.ChangeStatus(ctx, bidID, status)
+	return bid, isWinner, nil
+}
+
+// outcome tallies the votes on the bid and settles it by the decision rule of the tender
+func (s *service) outcome(ctx context.Context, rule quorum.Rule, policy model.DecisionPolicy, tenderOrganizationID string, bidID string) (quorum.Outcome, error) {
+	approvals, rejections, err := s.decisionService.DecisionStats(ctx, bidID)
 	if err != nil {
-		switch {
-		case errors.Is(err, repository_bid.ErrInvalidBidStatus):
-			return model.Bid{}, service_bids.ErrInvalidBidStatus
-		case errors.Is(err, repository_bid.ErrNoBids):
-			return model.Bid{}, service_bids.ErrNoBids
-		default:
-			return model.Bid{}, service_bids.ErrInternal
+		return quorum.Pending, err
+	}
+
+	representatives, err := s.organizationRespService.OrganizationRepresentativesAmount(ctx, tenderOrganizationID)
+	if err != nil {
+		return quorum.Pending, err
+	}
+
+	tally := quorum.Tally{
+		Representatives: representatives,
+		Approvals:       approvals,
+		Rejections:      rejections,
+	}
+
+	if policy.ApproverID != nil {
+		tally.ApproverDecision, err = s.decisionService.Decision(ctx, bidID, *policy.ApproverID)
+		if err != nil && !errors.Is(err, service_decision.ErrNoVotes) {
+			return quorum.Pending, err
 		}
 	}
 
-	return bid, nil
+	return rule.Outcome(tally), nil
 }
 
-func (s *service) Edit(ctx context.Context, bidID string, username string, bid model.Bid) (model.Bid, error) {
-	//CHECK ACCESS
-	userID, organizationID, err := s.organizationIDAndUserIDByUsername(ctx, username)
-	if err != nil {
-		return model.Bid{}, err
+// award closes the tender with the bid as its winner. It must run under the tender lock
+func (s *service) award(ctx context.Context, tenderID string, bidID string) error {
+	if err := s.tenderService.ChangeTenderStatusForce(ctx, tenderID, tenderClosedStatus); err != nil {
+		return err
 	}
+	return s.decisionService.Award(ctx, tenderID, bidID)
+}
+
+func (s *service) SettleOpenBids(ctx context.Context, organizationIDs []string) error {
+	return s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		bidIDsByTender, err := s.bidsRepository.OpenBids(ctx, organizationIDs)
+		if err != nil {
+			return service_bids.ErrInternal
+		}
 
-	authorID, err := s.bidsRepository.BidAuthorID(ctx, bidID)
+		for tenderID, bidIDs := range bidIDsByTender {
+			if err = s.settleTender(ctx, tenderID, bidIDs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// settleTender awards the tender to the first of the bids that meets its quorum, if any does
+func (s *service) settleTender(ctx context.Context, tenderID string, bidIDs []string) error {
+	//LOCK TENDER, THE SAME WAY A DECISION DOES
+	tenderOrganizationID, status, err := s.tenderService.TenderStatusForUpdate(ctx, tenderID)
 	if err != nil {
-		return model.Bid{}, service_bids.ErrInternal
+		return err
 	}
-
-	if authorID != userID && authorID != organizationID {
-		return model.Bid{}, service_bids.ErrForbidden
+	if status != tenderPublishedStatus {
+		return nil
 	}
 
-	//EDIT
-	updatedBid, err := s.bidsRepository.Edit(ctx, bidID, bid)
+	decisionPolicy, err := s.tenderService.DecisionPolicy(ctx, tenderID)
 	if err != nil {
-		switch {
-		case errors.Is(err, repository_bid.ErrNoSuggestionToUpdate):
-			return model.Bid{}, service_bids.ErrNoSuggestionToUpdate
-		case errors.Is(err, repository_bid.ErrNoBids):
-			return model.Bid{}, service_bids.ErrNoBids
-		default:
-			return model.Bid{}, service_bids.ErrInternal
+		return err
+	}
+	rule, err := s.decisionRules.Rule(decisionPolicy)
+	if err != nil {
+		l := logger.EndToEndLogging(ctx, s.logger)
+		l.Error("Failed to build tender decision rule", "tender_id", tenderID, "error", err.Error())
+		return service_bids.ErrInternal
+	}
+
+	for _, bidID := range bidIDs {
+		outcome, err := s.outcome(ctx, rule, decisionPolicy, tenderOrganizationID, bidID)
+		if err != nil {
+			return err
+		}
+
+		if outcome == quorum.Accepted {
+			return s.award(ctx, tenderID, bidID)
 		}
 	}
 
-	return updatedBid, nil
+	return nil
 }
 
-func (s *service) SubmitDecision(ctx context.Context, bidID string, decision string, username string) (bid model.Bid, isWinner bool, err error) {
-	//CHECK USER ACCESS
-	userID, userOrganizationID, err := s.organizationIDAndUserIDByUsername(ctx, username)
-	if err != nil {
-		return model.Bid{}, false, err
+func (s *service) submitDecision(ctx context.Context, bidID string, decision string) (bid model.Bid, isWinner bool, err error) {
+	//CHECK USER ACCESS. ONLY THE ORGANIZATION THAT LAUNCHED THE TENDER DECIDES, AND ONLY ON A PUBLISHED BID
+	if err = s.policy.CanDecideOnBid(ctx, bidID); err != nil {
+		return model.Bid{}, false, accessError(err, service_bids.ErrNoBids)
 	}
 
-	//GET ORGANIZATION THAT LAUNCHED TENDER
+	identity, _ := auth.IdentityFromContext(ctx)
+	userID := identity.EmployeeID
+
 	tenderID, err := s.bidsRepository.BidTenderID(ctx, bidID)
 	if err != nil {
 		return model.Bid{}, false, service_bids.ErrInternal
 	}
 
-	tenderOrganizationID, err := s.tenderService.TenderOrganizationID(ctx, tenderID)
+	//LOCK TENDER. CONCURRENT DECISIONS ON THE SAME TENDER WAIT HERE UNTIL THE CURRENT ONE COMMITS
+	tenderOrganizationID, status, err := s.tenderService.TenderStatusForUpdate(ctx, tenderID)
 	if err != nil {
 		return model.Bid{}, false, err
 	}
 
-	//CHECK IF USER ORGANIZATION LAUNCHED TENDER
-	if tenderOrganizationID != userOrganizationID {
-		return model.Bid{}, false, service_bids.ErrForbidden
+	//CHECK TENDER STATUS
+	if status == tenderClosedStatus {
+		return model.Bid{}, false, service_bids.ErrTenderClosed
 	}
 
-	//CHECK TENDER STATUS
-	_, status, err := s.tenderService.TenderStatus(ctx, tenderID)
+	//THE POLICY IS READ UNDER THE LOCK, SO AN EDIT OF THE TENDER CAN NOT CHANGE IT HALFWAY
+	decisionPolicy, err := s.tenderService.DecisionPolicy(ctx, tenderID)
 	if err != nil {
 		return model.Bid{}, false, err
 	}
-
-	if status == tenderClosedStatus {
-		return model.Bid{}, false, service_bids.ErrTenderClosed
+	rule, err := s.decisionRules.Rule(decisionPolicy)
+	if err != nil {
+		l := logger.EndToEndLogging(ctx, s.logger)
+		l.Error("Failed to build tender decision rule", "tender_id", tenderID, "error", err.Error())
+		return model.Bid{}, false, service_bids.ErrInternal
 	}
 
-	//GET DECISION STATISTIC
-	applied, rejected, err := s.decisionService.DecisionStats(ctx, bidID)
+	//CHECK THE BID HAS NOT BEEN REJECTED ALREADY
+	outcome, err := s.outcome(ctx, rule, decisionPolicy, tenderOrganizationID, bidID)
 	if err != nil {
 		return model.Bid{}, false, err
 	}
-	if rejected != 0 {
+	if outcome == quorum.Rejected {
 		return model.Bid{}, false, service_bids.ErrBidBeenRejected
 	}
 
@@ -256,15 +487,14 @@ func (s *service) SubmitDecision(ctx context.Context, bidID string, decision str
 		return model.Bid{}, false, err
 	}
 
-	//ORGANIZATION REPRESENTATIVES AMOUNT
-	representatives, err := s.organizationRespService.OrganizationRepresentativesAmount(ctx, tenderOrganizationID)
+	//SETTLE THE BID INCLUDING THE VOTE JUST CAST
+	outcome, err = s.outcome(ctx, rule, decisionPolicy, tenderOrganizationID, bidID)
 	if err != nil {
 		return model.Bid{}, false, err
 	}
 
-	//QUORUM CHECK
-	if float64(representatives)/2 <= float64(applied) {
-		if err = s.tenderService.ChangeTenderStatusForce(ctx, tenderID, tenderClosedStatus); err != nil {
+	if outcome == quorum.Accepted {
+		if err = s.award(ctx, tenderID, bidID); err != nil {
 			return model.Bid{}, false, err
 		}
 		isWinner = true
@@ -279,113 +509,274 @@ func (s *service) SubmitDecision(ctx context.Context, bidID string, decision str
 			return model.Bid{}, false, service_bids.ErrInternal
 		}
 	}
+
+	//RECORD THE DECISION EVENT. THE TENDER CLOSED EVENT HAS BEEN RECORDED BY THE TENDER SERVICE
+	payload := model.DecisionEventPayload{
+		BidID:        bidID,
+		TenderID:     tenderID,
+		Decision:     decision,
+		DecidedBy:    userID,
+		TenderClosed: isWinner,
+	}
+	if err = s.outboxService.Publish(ctx, model.EventBidDecision, model.AggregateBid, bidID, audience(bid, tenderOrganizationID), payload); err != nil {
+		return model.Bid{}, false, service_bids.ErrInternal
+	}
+
 	return bid, isWinner, nil
 }
 
-func (s *service) Feedback(ctx context.Context, bidID string, username string, feedback string) (model.Bid, error) {
-	//CHECK ACCESS
-	tenderID, err := s.bidsRepository.BidTenderID(ctx, bidID)
+func (s *service) Feedback(ctx context.Context, bidID string, feedback string, rating *int) (model.Bid, error) {
+	//CHECK ACCESS. ONLY THE ORGANIZATION THAT LAUNCHED THE TENDER REVIEWS ITS BIDS
+	if err := s.policy.CanReviewBid(ctx, bidID); err != nil {
+		return model.Bid{}, accessError(err, service_bids.ErrNoBids)
+	}
+
+	identity, _ := auth.IdentityFromContext(ctx)
+
+	bid, err := s.bidsRepository.BidByID(ctx, bidID)
 	if err != nil {
-		return model.Bid{}, service_bids.ErrInternal
+		switch {
+		case errors.Is(err, repository_bid.ErrNoBids):
+			return model.Bid{}, service_bids.ErrNoBids
+		default:
+			return model.Bid{}, service_bids.ErrInternal
+		}
 	}
 
-	userID, err := s.employeeService.IDByUsername(ctx, username)
+	//THE REVIEW IS ABOUT THE BID AUTHOR, SO IT SHOWS UP IN THE AUTHOR TRACK RECORD ON LATER TENDERS
+	_, err = s.feedbackService.Feedback(ctx, model.Feedback{
+		BidID:       *bid.ID,
+		TenderID:    *bid.TenderID,
+		AuthorType:  *bid.AuthorType,
+		AuthorID:    *bid.AuthorID,
+		ReviewerID:  identity.EmployeeID,
+		Description: feedback,
+		Rating:      rating,
+	})
 	if err != nil {
 		return model.Bid{}, service_bids.ErrInternal
 	}
 
-	organizationID, err := s.organizationRespService.GetOrganizationIDByRepresentative(ctx, userID)
+	return bid, nil
+}
+
+func (s *service) RollbackVersion(ctx context.Context, bidID string, version int) (model.Bid, error) {
+	//CHECK ACCESS
+	if err := s.policy.CanEditBid(ctx, bidID); err != nil {
+		return model.Bid{}, accessError(err, service_bids.ErrNoBids)
+	}
+
+	identity, _ := auth.IdentityFromContext(ctx)
+	userID := identity.EmployeeID
+
+	var bid model.Bid
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		currentStatus, tenderID, _, txErr := s.bidsRepository.GetStatus(ctx, bidID)
+		if txErr != nil {
+			switch {
+			case errors.Is(txErr, repository_bid.ErrNoBids):
+				return service_bids.ErrNoBids
+			default:
+				return service_bids.ErrInternal
+			}
+		}
+
+		tenderOrganizationID, txErr := s.lockOpenTender(ctx, tenderID)
+		if txErr != nil {
+			return txErr
+		}
+
+		//A ROLLBACK RESTORES THE STATUS AS WELL, SO IT MUST NOT BYPASS THE TRANSITION TABLE
+		target, txErr := s.version(ctx, bidID, version)
+		if txErr != nil {
+			return txErr
+		}
+
+		if *target.Bid.Status != currentStatus && !slices.Contains(bidTransitions[currentStatus], *target.Bid.Status) {
+			return service_bids.ErrInvalidStatusTransition
+		}
+
+		bid, txErr = s.bidsRepository.RollbackVersion(ctx, bidID, version, userID)
+		if txErr != nil {
+			switch {
+			case errors.Is(txErr, repository_bid.ErrNoBids):
+				return service_bids.ErrNoBids
+			default:
+				return service_bids.ErrInternal
+			}
+		}
+
+		if *bid.Status == currentStatus || *bid.Status != model.BidStatusPublished {
+			return nil
+		}
+		return s.publishSubmitted(ctx, bid, tenderID, tenderOrganizationID)
+	})
 	if err != nil {
 		return model.Bid{}, err
 	}
 
-	exists, err := s.tenderService.ConfirmTenderCreator(ctx, tenderID, organizationID)
+	return bid, nil
+}
+
+func (s *service) GetReviews(ctx context.Context, tenderID string, authorUsername string, page model.Page) ([]model.Feedback, model.FeedbackStats, model.PageInfo, error) {
+	//CHECK ACCESS. REVIEWS ARE READ BY THE ORGANIZATION THAT LAUNCHED THE TENDER
+	if err := s.policy.CanManageTender(ctx, tenderID); err != nil {
+		return nil, model.FeedbackStats{}, model.PageInfo{}, accessError(err, service_tenders.ErrNoTenders)
+	}
+
+	authors, err := s.authors(ctx, authorUsername)
 	if err != nil {
-		return model.Bid{}, err
+		return nil, model.FeedbackStats{}, model.PageInfo{}, err
 	}
 
-	if !exists {
-		return model.Bid{}, service_bids.ErrForbidden
+	//ONLY AUTHORS WHO BID ON THE TENDER ARE LOOKED INTO
+	hasBid, err := s.bidsRepository.AuthorHasBid(ctx, tenderID, authors)
+	if err != nil {
+		return nil, model.FeedbackStats{}, model.PageInfo{}, service_bids.ErrInternal
+	}
+	if !hasBid {
+		return nil, model.FeedbackStats{}, model.PageInfo{}, service_bids.ErrNoBids
 	}
 
-	bid, err := s.bidsRepository.BidByID(ctx, bidID)
+	reviews, stats, pageInfo, err := s.feedbackService.GetReviews(ctx, authors, page)
+	if err != nil {
+		return nil, model.FeedbackStats{}, model.PageInfo{}, err
+	}
+	return reviews, stats, pageInfo, nil
+}
+
+// authors returns the user and the organizations it represents, bids of any of them are the user's work
+func (s *service) authors(ctx context.Context, username string) ([]model.BidAuthor, error) {
+	userID, err := s.employeeService.IDByUsername(ctx, username)
 	if err != nil {
 		switch {
-		case errors.Is(err, repository_bid.ErrNoBids):
-			return model.Bid{}, service_bids.ErrNoBids
+		case errors.Is(err, service_employee.ErrNonExistingEmployee):
+			return nil, service_bids.ErrNoBids
 		default:
-			return model.Bid{}, service_bids.ErrInternal
+			return nil, service_bids.ErrInternal
 		}
 	}
 
-	err = s.feedbackService.Feedback(ctx, userID, feedback)
+	authors := []model.BidAuthor{{Type: model.BidAuthorUser, ID: userID}}
+
+	organizationIDs, err := s.organizationRespService.OrganizationIDsByRepresentative(ctx, userID)
 	if err != nil {
-		return model.Bid{}, service_bids.ErrInternal
+		switch {
+		case errors.Is(err, service_organization_resp.ErrUserHasNoOrganization):
+			return authors, nil
+		default:
+			return nil, service_bids.ErrInternal
+		}
 	}
 
-	return bid, nil
+	for _, organizationID := range organizationIDs {
+		authors = append(authors, model.BidAuthor{Type: model.BidAuthorOrganization, ID: organizationID})
+	}
+
+	return authors, nil
 }
 
-func (s *service) RollbackVersion(ctx context.Context, bidID string, username string, version int) (model.Bid, error) {
-	//CHECK ACCESS
-	userID, organizationID, err := s.organizationIDAndUserIDByUsername(ctx, username)
+// lockOpenTender locks the tender until the surrounding transaction ends and rejects changes once it is closed
+func (s *service) lockOpenTender(ctx context.Context, tenderID string) (tenderOrganizationID string, err error) {
+	tenderOrganizationID, tenderStatus, err := s.tenderService.TenderStatusForUpdate(ctx, tenderID)
 	if err != nil {
-		return model.Bid{}, err
+		return "", err
 	}
 
-	authorID, err := s.bidsRepository.BidAuthorID(ctx, bidID)
-	if err != nil {
-		return model.Bid{}, service_bids.ErrInternal
+	if tenderStatus == tenderClosedStatus {
+		return "", service_bids.ErrTenderClosed
 	}
 
-	if authorID != userID && authorID != organizationID {
-		return model.Bid{}, service_bids.ErrForbidden
+	return tenderOrganizationID, nil
+}
+
+func (s *service) Versions(ctx context.Context, bidID string) ([]model.BidVersion, error) {
+	//CHECK ACCESS
+	if err := s.policy.CanViewBid(ctx, bidID); err != nil {
+		return nil, accessError(err, service_bids.ErrNoBids)
 	}
 
-	bid, err := s.bidsRepository.RollbackVersion(ctx, bidID, version)
+	versions, err := s.bidsRepository.Versions(ctx, bidID)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository_bid.ErrNoBids):
-			return model.Bid{}, service_bids.ErrNoBids
+			return nil, service_bids.ErrNoBids
 		default:
-			return model.Bid{}, service_bids.ErrInternal
+			return nil, service_bids.ErrInternal
 		}
 	}
 
-	return bid, nil
+	return versions, nil
 }
 
-func (s *service) GetReviews(ctx context.Context, tenderID string, authorUsername, requesterUsername string, limit int, offset int) ([]model.Feedback, error) {
+func (s *service) Version(ctx context.Context, bidID string, version int) (model.BidVersion, error) {
 	//CHECK ACCESS
-	_, organizationID, err := s.organizationIDAndUserIDByUsername(ctx, requesterUsername)
-	if err != nil {
-		return nil, err
+	if err := s.policy.CanViewBid(ctx, bidID); err != nil {
+		return model.BidVersion{}, accessError(err, service_bids.ErrNoBids)
 	}
 
-	tenderOrganizationID, err := s.tenderService.TenderOrganizationID(ctx, tenderID)
+	return s.version(ctx, bidID, version)
+}
+
+func (s *service) version(ctx context.Context, bidID string, version int) (model.BidVersion, error) {
+	bidVersion, err := s.bidsRepository.Version(ctx, bidID, version)
 	if err != nil {
-		return nil, err
+		switch {
+		case errors.Is(err, repository_bid.ErrNoBids):
+			return model.BidVersion{}, service_bids.ErrNoBids
+		default:
+			return model.BidVersion{}, service_bids.ErrInternal
+		}
+	}
+
+	return bidVersion, nil
+}
+
+func (s *service) Diff(ctx context.Context, bidID string, from int, to int) (model.VersionDiff, error) {
+	//CHECK ACCESS
+	if err := s.policy.CanViewBid(ctx, bidID); err != nil {
+		return model.VersionDiff{}, accessError(err, service_bids.ErrNoBids)
 	}
 
-	if organizationID != tenderOrganizationID {
-		return nil, service_bids.ErrForbidden
+	fromVersion, err := s.version(ctx, bidID, from)
+	if err != nil {
+		return model.VersionDiff{}, err
 	}
 
-	reviews, err := s.feedbackService.GetReviews(ctx, authorUsername, limit, offset)
+	toVersion, err := s.version(ctx, bidID, to)
 	if err != nil {
-		return nil, err
+		return model.VersionDiff{}, err
 	}
-	return reviews, nil
+
+	diff := model.VersionDiff{
+		From:       from,
+		To:         to,
+		ChangedAt:  toVersion.ChangedAt,
+		ChangedBy:  toVersion.Bid.ChangedBy,
+		ChangeKind: toVersion.Bid.ChangeKind,
+		Changes:    make([]model.FieldChange, 0),
+	}
+	diff.Compare(model.FieldName, fromVersion.Bid.Name, toVersion.Bid.Name)
+	diff.Compare(model.FieldDescription, fromVersion.Bid.Description, toVersion.Bid.Description)
+	diff.Compare(model.FieldStatus, fromVersion.Bid.Status, toVersion.Bid.Status)
+	diff.Compare(model.FieldAuthorType, fromVersion.Bid.AuthorType, toVersion.Bid.AuthorType)
+	diff.Compare(model.FieldAuthorID, fromVersion.Bid.AuthorID, toVersion.Bid.AuthorID)
+
+	return diff, nil
 }
 
-func New(bidsRepository repository_bid.Repository, employeeService service_employee.Service, organizationRespService service_organization_resp.Service, tenderService service_tenders.Service, decisionService service_decision.Service, feedbackService service_feedback.Service, logger *slog.Logger) service_bids.Service {
+func New(bidsRepository repository_bid.Repository, txManager postgres.TxManager, policy authz.Policy, employeeService service_employee.Service, organizationRespService service_organization_resp.Service, tenderService service_tenders.Service, decisionService service_decision.Service, feedbackService service_feedback.Service, outboxService service_outbox.Service, decisionRules *quorum.Registry, logger *slog.Logger) service_bids.Service {
 	s := &service{
 		bidsRepository:          bidsRepository,
+		txManager:               txManager,
+		policy:                  policy,
 		employeeService:         employeeService,
 		tenderService:           tenderService,
 		decisionService:         decisionService,
 		feedbackService:         feedbackService,
 		organizationRespService: organizationRespService,
+		outboxService:           outboxService,
+		decisionRules:           decisionRules,
 		logger:                  logger,
 	}
 
@@ -0,0 +1,275 @@
+package service_bids_impl_test
+
+import (
+	"avito_intership/internal/auth"
+	"avito_intership/internal/authz"
+	"avito_intership/internal/migrator"
+	"avito_intership/internal/model"
+	"avito_intership/internal/quorum"
+	repository_bid_postgres "avito_intership/internal/repository/bid/postgres"
+	repository_decision_postgres "avito_intership/internal/repository/decision/postgres"
+	repository_employee_postgres "avito_intership/internal/repository/employee/postgres"
+	repository_feedback_postgres "avito_intership/internal/repository/feedback/postgres"
+	repository_organization_postgres "avito_intership/internal/repository/organization/postgres"
+	repository_organization_resp_postgres "avito_intership/internal/repository/organization_responsible/postgres"
+	repository_outbox_postgres "avito_intership/internal/repository/outbox/postgres"
+	repository_tenders_postgres "avito_intership/internal/repository/tender/postgres"
+	service_bids "avito_intership/internal/service/bid"
+	service_bids_impl "avito_intership/internal/service/bid/implementation"
+	service_decision "avito_intership/internal/service/decision"
+	service_decision_impl "avito_intership/internal/service/decision/implementation"
+	service_employee_impl "avito_intership/internal/service/employee/implementation"
+	service_feedback_impl "avito_intership/internal/service/feedback/implementation"
+	service_organization_impl "avito_intership/internal/service/organization/implementation"
+	service_organization_resp_impl "avito_intership/internal/service/organization_responsible/implementation"
+	service_outbox_impl "avito_intership/internal/service/outbox/implementation"
+	service_tenders_impl "avito_intership/internal/service/tender/implementation"
+	"avito_intership/pkg/postgres"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testConnEnv names a throwaway database the test may migrate and write to. The test is skipped without it.
+const testConnEnv = "TEST_POSTGRES_CONN"
+
+// prerequisites are the objects the migrations expect to exist already, see README
+const prerequisites = `
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+DO $$ BEGIN
+    CREATE TYPE organization_type AS ENUM ('IE', 'LLC', 'JSC');
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE TABLE IF NOT EXISTS employee (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    username VARCHAR(50) UNIQUE NOT NULL,
+    first_name VARCHAR(50),
+    last_name VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS organization (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    type organization_type,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS organization_responsible (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES employee(id) ON DELETE CASCADE
+);`
+
+func testDB(t *testing.T) *postgres.DB {
+	t.Helper()
+
+	connStr := os.Getenv(testConnEnv)
+	if connStr == "" {
+		t.Skipf("%s is not set", testConnEnv)
+	}
+
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	if _, err = pool.Exec(ctx, prerequisites); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := migrator.New(connStr, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if err = m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	return postgres.New(pool)
+}
+
+func newService(db *postgres.DB) service_bids.Service {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	txManager := postgres.NewTxManager(db)
+
+	tendersRepository := repository_tenders_postgres.New(db, logger)
+	bidRepository := repository_bid_postgres.New(db, logger)
+	policy := authz.New(tendersRepository, bidRepository, logger)
+
+	employeeService := service_employee_impl.New(repository_employee_postgres.New(db, logger), logger)
+	organizationService := service_organization_impl.New(repository_organization_postgres.New(db, logger), logger)
+	settler := quorum.SettlerFunc(func(context.Context, []string) error { return nil })
+	organizationRespService := service_organization_resp_impl.New(repository_organization_resp_postgres.New(db, logger), txManager, organizationService,
+		employeeService, settler, logger)
+	outboxService := service_outbox_impl.New(repository_outbox_postgres.New(db, logger), 10, time.Second, time.Minute, logger)
+	decisionRules := quorum.New()
+
+	tenderService := service_tenders_impl.New(tendersRepository, txManager, policy, organizationRespService, outboxService, decisionRules, settler, logger)
+	decisionService := service_decision_impl.New(repository_decision_postgres.New(db, logger), logger)
+	feedbackService := service_feedback_impl.New(repository_feedback_postgres.New(db, logger), logger)
+
+	return service_bids_impl.New(bidRepository, txManager, policy, employeeService, organizationRespService, tenderService, decisionService,
+		feedbackService, outboxService, decisionRules, logger)
+}
+
+// seed creates an organization with the representatives, a published tender decided by the policy and the bids on it.
+// Everything is removed when the test ends.
+func seed(t *testing.T, db *postgres.DB, representatives int, policy string, requiredApprovals *int, bids int) ([]auth.Identity, string, []string) {
+	t.Helper()
+
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+
+	var organizationID, bidderID, tenderID string
+	if err := db.QueryRow(ctx, "INSERT INTO organization (name, type) VALUES ($1, 'LLC') RETURNING id",
+		fmt.Sprintf("quorum-%d", suffix)).Scan(&organizationID); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _ = db.Exec(context.Background(), "DELETE FROM organization WHERE id = $1", organizationID)
+		_, _ = db.Exec(context.Background(), "DELETE FROM employee WHERE username LIKE $1", fmt.Sprintf("%%-%d", suffix))
+	})
+
+	if err := db.QueryRow(ctx, "INSERT INTO employee (username) VALUES ($1) RETURNING id",
+		fmt.Sprintf("bidder-%d", suffix)).Scan(&bidderID); err != nil {
+		t.Fatal(err)
+	}
+
+	identities := make([]auth.Identity, 0, representatives)
+	for i := 0; i < representatives; i++ {
+		identity := auth.Identity{Username: fmt.Sprintf("rep%d-%d", i, suffix), OrganizationIDs: []string{organizationID}}
+		if err := db.QueryRow(ctx, "INSERT INTO employee (username) VALUES ($1) RETURNING id", identity.Username).Scan(&identity.EmployeeID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(ctx, "INSERT INTO organization_responsible (organization_id, user_id) VALUES ($1, $2)", organizationID, identity.EmployeeID); err != nil {
+			t.Fatal(err)
+		}
+		identities = append(identities, identity)
+	}
+
+	if err := db.QueryRow(ctx, `INSERT INTO tender (name, description, service_type, status, organization_id, creator_username, decision_policy, required_approvals)
+		VALUES ('tender', 'tender', 'Delivery', 'Published', $1, $2, $3, $4) RETURNING id`,
+		organizationID, identities[0].Username, policy, requiredApprovals).Scan(&tenderID); err != nil {
+		t.Fatal(err)
+	}
+
+	bidIDs := make([]string, 0, bids)
+	for i := 0; i < bids; i++ {
+		var bidID string
+		if err := db.QueryRow(ctx, `INSERT INTO bid (name, description, status, tender_id, author_type, author_id)
+			VALUES ($1, 'bid', 'Published', $2, 'User', $3) RETURNING id`, fmt.Sprintf("bid-%d", i), tenderID, bidderID).Scan(&bidID); err != nil {
+			t.Fatal(err)
+		}
+		bidIDs = append(bidIDs, bidID)
+	}
+
+	return identities, tenderID, bidIDs
+}
+
+// TestSubmitDecisionSingleWinner lets every representative approve a different bid of one tender at the same moment.
+// A single approval settles a bid, so the tender lock must let exactly one of them win.
+func TestSubmitDecisionSingleWinner(t *testing.T) {
+	const representatives = 8
+
+	db := testDB(t)
+	service := newService(db)
+	ctx := context.Background()
+
+	requiredApprovals := 1
+	identities, tenderID, bidIDs := seed(t, db, representatives, model.DecisionPolicyFixedApprovals, &requiredApprovals, representatives)
+
+	type result struct {
+		isWinner bool
+		err      error
+	}
+
+	results := make([]result, representatives)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < representatives; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, isWinner, err := service.SubmitDecision(auth.WithIdentity(ctx, identities[i]), bidIDs[i], model.DecisionApproved)
+			results[i] = result{isWinner: isWinner, err: err}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	winners := 0
+	for i, r := range results {
+		switch {
+		case r.err == nil && r.isWinner:
+			winners++
+		case errors.Is(r.err, service_bids.ErrTenderClosed):
+		default:
+			t.Errorf("decision %d: winner %v, error %v", i, r.isWinner, r.err)
+		}
+	}
+	if winners != 1 {
+		t.Errorf("expected exactly one winner, got %d", winners)
+	}
+
+	var awards int
+	if err := db.QueryRow(ctx, "SELECT COUNT(*) FROM tender_award WHERE tender_id = $1", tenderID).Scan(&awards); err != nil {
+		t.Fatal(err)
+	}
+	if awards != 1 {
+		t.Errorf("expected exactly one award, got %d", awards)
+	}
+
+	var status string
+	if err := db.QueryRow(ctx, "SELECT status FROM tender WHERE id = $1", tenderID).Scan(&status); err != nil {
+		t.Fatal(err)
+	}
+	if status != model.TenderStatusClosed {
+		t.Errorf("expected the tender to be closed, got %s", status)
+	}
+}
+
+// TestSubmitDecisionOnEveryBid lets one representative decide on two bids of one tender, votes are counted per bid.
+func TestSubmitDecisionOnEveryBid(t *testing.T) {
+	db := testDB(t)
+	service := newService(db)
+
+	identities, _, bidIDs := seed(t, db, 3, model.DecisionPolicyMajority, nil, 2)
+	ctx := auth.WithIdentity(context.Background(), identities[0])
+
+	for _, bidID := range bidIDs {
+		if _, isWinner, err := service.SubmitDecision(ctx, bidID, model.DecisionApproved); err != nil || isWinner {
+			t.Fatalf("decision on bid %s: winner %v, error %v", bidID, isWinner, err)
+		}
+	}
+
+	//THE SAME BID IS STILL DECIDED ONCE
+	if _, _, err := service.SubmitDecision(ctx, bidIDs[0], model.DecisionRejected); !errors.Is(err, service_decision.ErrUserAlreadyVoted) {
+		t.Errorf("expected a repeated decision to be rejected, got %v", err)
+	}
+
+	//THE OTHER REPRESENTATIVES MAKE UP THE MAJORITY ON THE SECOND BID
+	_, isWinner, err := service.SubmitDecision(auth.WithIdentity(context.Background(), identities[1]), bidIDs[1], model.DecisionApproved)
+	if err != nil || !isWinner {
+		t.Errorf("expected the second bid to win, winner %v, error %v", isWinner, err)
+	}
+}
@@ -6,20 +6,32 @@ import (
 )
 
 type Service interface {
+	//Create creates a bid on behalf of the caller or of an organization it represents, named by the author id
 	Create(ctx context.Context, bid model.Bid) (model.Bid, error)
-	//BidsByUser returns a list of bids by username (on behalf of the organization and on behalf of the user)
-	BidsByUser(ctx context.Context, username string, limit int, offset int) ([]model.Bid, error)
+	//BidsByUser returns a list of the caller bids (on behalf of the organization and on behalf of the user)
+	BidsByUser(ctx context.Context, page model.Page) ([]model.Bid, model.PageInfo, error)
 	//BidsByTenderID can use tender creators only
-	BidsByTenderID(ctx context.Context, tenderID string, username string, limit int, offset int) ([]model.Bid, error)
+	BidsByTenderID(ctx context.Context, tenderID string, page model.Page) ([]model.Bid, model.PageInfo, error)
 	//GetStatus can use tender creators or bid authors
-	GetStatus(ctx context.Context, bidID string, username string) (status string, err error)
+	GetStatus(ctx context.Context, bidID string) (status string, err error)
 	//ChangeStatus can use bid creators only
-	ChangeStatus(ctx context.Context, bidID string, username string, status string) (bid model.Bid, err error)
-	//Edit can use bid creators only
-	Edit(ctx context.Context, bidID string, username string, bid model.Bid) (model.Bid, error)
-	SubmitDecision(ctx context.Context, bidID string, decision string, username string) (bid model.Bid, isWinner bool, err error)
-	Feedback(ctx context.Context, bidID string, username string, feedback string) (model.Bid, error)
+	ChangeStatus(ctx context.Context, bidID string, status string) (bid model.Bid, err error)
+	//Edit can use bid creators only. When expectedVersion is set and the bid has moved on, the current bid is returned with ErrVersionConflict
+	Edit(ctx context.Context, bidID string, bid model.Bid, expectedVersion *int) (model.Bid, error)
+	SubmitDecision(ctx context.Context, bidID string, decision string) (bid model.Bid, isWinner bool, err error)
+	//SettleOpenBids settles the bids pending on open tenders of the organizations by their decisions cast so far,
+	//closing a tender whose bid meets the quorum. It joins the caller transaction and checks no access
+	SettleOpenBids(ctx context.Context, organizationIDs []string) error
+	//Feedback reviews the bid author, the rating is optional. Reviews are left by the organization that launched the tender
+	Feedback(ctx context.Context, bidID string, feedback string, rating *int) (model.Bid, error)
 	//RollbackVersion can use bid creators only
-	RollbackVersion(ctx context.Context, bidID string, username string, version int) (model.Bid, error)
-	GetReviews(ctx context.Context, tenderID string, authorUsername, requesterUsername string, limit int, offset int) ([]model.Feedback, error)
+	RollbackVersion(ctx context.Context, bidID string, version int) (model.Bid, error)
+	//GetReviews returns the reviews on past bids of an author who bid on the tender, the user and the organization
+	//it represents, with rating stats over all of them
+	GetReviews(ctx context.Context, tenderID string, authorUsername string, page model.Page) ([]model.Feedback, model.FeedbackStats, model.PageInfo, error)
+	//Versions returns the version history of the bid, oldest first, to its author and to the tender owner
+	Versions(ctx context.Context, bidID string) ([]model.BidVersion, error)
+	Version(ctx context.Context, bidID string, version int) (model.BidVersion, error)
+	//Diff returns the fields changed between two versions of the bid
+	Diff(ctx context.Context, bidID string, from int, to int) (model.VersionDiff, error)
 }
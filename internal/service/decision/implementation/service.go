@@ -42,6 +42,32 @@ func (s *service) DecisionStats(ctx context.Context, bidID string) (applied int,
 	return applied, rejected, nil
 }
 
+func (s *service) Decision(ctx context.Context, bidID string, authorID string) (decision string, err error) {
+	decision, err = s.repository.Decision(ctx, bidID, authorID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_decision.ErrNoVotes):
+			return "", service_decision.ErrNoVotes
+		default:
+			return "", service_decision.ErrInternal
+		}
+	}
+	return decision, nil
+}
+
+func (s *service) Award(ctx context.Context, tenderID string, bidID string) error {
+	if err := s.repository.Award(ctx, tenderID, bidID); err != nil {
+		switch {
+		case errors.Is(err, repository_decision.ErrInvalidForeignKey):
+			return service_decision.ErrInvalidReference
+		default:
+			return service_decision.ErrInternal
+		}
+	}
+
+	return nil
+}
+
 func New(repository repository_decision.Repository, logger *slog.Logger) service_decision.Service {
 	s := &service{
 		repository: repository,
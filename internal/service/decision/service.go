@@ -5,4 +5,8 @@ import "context"
 type Service interface {
 	SubmitDecision(ctx context.Context, authorID string, tenderID string, bidID string, decision string) error
 	DecisionStats(ctx context.Context, bidID string) (applied int, rejected int, err error)
+	//Decision returns the decision of the author on the bid, ErrNoVotes until one is submitted
+	Decision(ctx context.Context, bidID string, authorID string) (decision string, err error)
+	//Award records the bid as the winner of its tender
+	Award(ctx context.Context, tenderID string, bidID string) error
 }
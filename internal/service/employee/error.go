@@ -3,6 +3,8 @@ package service_employee
 import "errors"
 
 var (
-	ErrInternal            = errors.New("internal error")
-	ErrNonExistingEmployee = errors.New("non existing employee")
+	ErrInternal             = errors.New("internal error")
+	ErrNonExistingEmployee  = errors.New("non existing employee")
+	ErrUsernameTaken        = errors.New("username is taken")
+	ErrNoSuggestionToUpdate = errors.New("no suggestion to update")
 )
@@ -1,10 +1,13 @@
 package service_employee_impl
 
 import (
+	"avito_intership/internal/model"
 	repository_employee "avito_intership/internal/repository/employee"
 	service_employee "avito_intership/internal/service/employee"
+	"avito_intership/pkg/logger"
 	"context"
 	"errors"
+	"golang.org/x/crypto/bcrypt"
 	"log/slog"
 )
 
@@ -28,6 +31,110 @@ func (s *service) IDByUsername(ctx context.Context, username string) (userID str
 	return userID, nil
 }
 
+func (s *service) UsernameByID(ctx context.Context, userID string) (username string, err error) {
+	username, err = s.repository.UsernameByID(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_employee.ErrNonExistingEmployee):
+			return "", service_employee.ErrNonExistingEmployee
+		default:
+			return "", service_employee.ErrInternal
+		}
+	}
+
+	return username, nil
+}
+
+func (s *service) CredentialsByUsername(ctx context.Context, username string) (userID string, passwordHash string, err error) {
+	userID, passwordHash, err = s.repository.CredentialsByUsername(ctx, username)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_employee.ErrNonExistingEmployee):
+			return "", "", service_employee.ErrNonExistingEmployee
+		default:
+			return "", "", service_employee.ErrInternal
+		}
+	}
+
+	return userID, passwordHash, nil
+}
+
+func employeeError(err error) error {
+	switch {
+	case errors.Is(err, repository_employee.ErrNonExistingEmployee):
+		return service_employee.ErrNonExistingEmployee
+	case errors.Is(err, repository_employee.ErrUsernameTaken):
+		return service_employee.ErrUsernameTaken
+	default:
+		return service_employee.ErrInternal
+	}
+}
+
+func (s *service) hashPassword(ctx context.Context, password *string) (*string, error) {
+	if password == nil {
+		return nil, nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(*password), bcrypt.DefaultCost)
+	if err != nil {
+		logger.EndToEndLogging(ctx, s.logger).Error("Failed to hash password", "error", err.Error())
+		return nil, service_employee.ErrInternal
+	}
+
+	passwordHash := string(hash)
+	return &passwordHash, nil
+}
+
+func (s *service) Create(ctx context.Context, employee model.Employee, password *string) (model.Employee, error) {
+	passwordHash, err := s.hashPassword(ctx, password)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	created, err := s.repository.Create(ctx, employee, passwordHash)
+	if err != nil {
+		return model.Employee{}, employeeError(err)
+	}
+
+	return created, nil
+}
+
+func (s *service) Employee(ctx context.Context, userID string) (model.Employee, error) {
+	employee, err := s.repository.Employee(ctx, userID)
+	if err != nil {
+		return model.Employee{}, employeeError(err)
+	}
+
+	return employee, nil
+}
+
+func (s *service) Update(ctx context.Context, userID string, patch model.EmployeePatch) (model.Employee, error) {
+	if patch == (model.EmployeePatch{}) {
+		return model.Employee{}, service_employee.ErrNoSuggestionToUpdate
+	}
+
+	passwordHash, err := s.hashPassword(ctx, patch.Password)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	employee, err := s.repository.Update(ctx, userID, patch, passwordHash)
+	if err != nil {
+		return model.Employee{}, employeeError(err)
+	}
+
+	return employee, nil
+}
+
+func (s *service) Deactivate(ctx context.Context, userID string) (model.Employee, error) {
+	employee, err := s.repository.Deactivate(ctx, userID)
+	if err != nil {
+		return model.Employee{}, employeeError(err)
+	}
+
+	return employee, nil
+}
+
 func New(repository repository_employee.Repository, logger *slog.Logger) service_employee.Service {
 	s := &service{
 		repository: repository,
@@ -1,7 +1,19 @@
 package service_employee
 
-import "context"
+import (
+	"avito_intership/internal/model"
+	"context"
+)
 
 type Service interface {
 	IDByUsername(ctx context.Context, username string) (userID string, err error)
+	UsernameByID(ctx context.Context, userID string) (username string, err error)
+	CredentialsByUsername(ctx context.Context, username string) (userID string, passwordHash string, err error)
+	//Create stores the employee, password is optional and hashed before it is stored
+	Create(ctx context.Context, employee model.Employee, password *string) (model.Employee, error)
+	Employee(ctx context.Context, userID string) (model.Employee, error)
+	Update(ctx context.Context, userID string, patch model.EmployeePatch) (model.Employee, error)
+	//Deactivate revokes the sign in and the votes of the employee, its tenders, bids and reviews are kept.
+	//Pending bids are not settled, organization_responsible DeactivateEmployee does that
+	Deactivate(ctx context.Context, userID string) (model.Employee, error)
 }
@@ -15,25 +15,43 @@ type service struct {
 	logger *slog.Logger
 }
 
-func (s *service) Feedback(ctx context.Context, userID string, feedback string) error {
-	if err := s.repository.Feedback(ctx, userID, feedback); err != nil {
-		return service_feedback.ErrInternal
+func (s *service) Feedback(ctx context.Context, feedback model.Feedback) (model.Feedback, error) {
+	created, err := s.repository.Feedback(ctx, feedback)
+	if err != nil {
+		return model.Feedback{}, service_feedback.ErrInternal
 	}
-	return nil
+	return created, nil
 }
 
-func (s *service) GetReviews(ctx context.Context, authorUsername string, limit int, offset int) ([]model.Feedback, error) {
-	feedbacks, err := s.repository.GetFeedbacks(ctx, authorUsername, limit, offset)
+func (s *service) GetReviews(ctx context.Context, authors []model.BidAuthor, page model.Page) ([]model.Feedback, model.FeedbackStats, model.PageInfo, error) {
+	stats, err := s.repository.Stats(ctx, authors)
+	if err != nil {
+		return nil, model.FeedbackStats{}, model.PageInfo{}, service_feedback.ErrInternal
+	}
+
+	if stats.Count == 0 {
+		return nil, model.FeedbackStats{}, model.PageInfo{}, service_feedback.ErrNoReviews
+	}
+
+	feedbacks, pageInfo, err := s.repository.GetFeedbacks(ctx, authors, page)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository_feedback.ErrNoReviews):
-			return nil, service_feedback.ErrNoReviews
+			return nil, model.FeedbackStats{}, model.PageInfo{}, service_feedback.ErrNoReviews
 		default:
-			return nil, service_feedback.ErrInternal
+			return nil, model.FeedbackStats{}, model.PageInfo{}, service_feedback.ErrInternal
 		}
 	}
 
-	return feedbacks, nil
+	return feedbacks, stats, pageInfo, nil
+}
+
+func (s *service) Stats(ctx context.Context, authors []model.BidAuthor) (model.FeedbackStats, error) {
+	stats, err := s.repository.Stats(ctx, authors)
+	if err != nil {
+		return model.FeedbackStats{}, service_feedback.ErrInternal
+	}
+	return stats, nil
 }
 
 func New(repository repository_feedback.Repository, logger *slog.Logger) service_feedback.Service {
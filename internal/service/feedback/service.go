@@ -6,6 +6,9 @@ import (
 )
 
 type Service interface {
-	Feedback(ctx context.Context, userID string, feedback string) error
-	GetReviews(ctx context.Context, authorUsername string, limit int, offset int) ([]model.Feedback, error)
+	Feedback(ctx context.Context, feedback model.Feedback) (model.Feedback, error)
+	//GetReviews returns a page of reviews on bids of any of the authors together with stats over all of them
+	GetReviews(ctx context.Context, authors []model.BidAuthor, page model.Page) ([]model.Feedback, model.FeedbackStats, model.PageInfo, error)
+	//Stats sums up the reviews on bids of any of the authors, an author without reviews gets zero stats
+	Stats(ctx context.Context, authors []model.BidAuthor) (model.FeedbackStats, error)
 }
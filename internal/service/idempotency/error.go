@@ -0,0 +1,10 @@
+package service_idempotency
+
+import "errors"
+
+var (
+	ErrInternal          = errors.New("internal error")
+	ErrKeyReused         = errors.New("idempotency key has already been used with a different request")
+	ErrRequestInProgress = errors.New("a request with this idempotency key is still in progress")
+	ErrLeaseLost         = errors.New("the request outlived its lease on the idempotency key")
+)
@@ -0,0 +1,85 @@
+package service_idempotency_impl
+
+import (
+	"avito_intership/internal/model"
+	repository_idempotency "avito_intership/internal/repository/idempotency"
+	service_idempotency "avito_intership/internal/service/idempotency"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+type service struct {
+	repository repository_idempotency.Repository
+
+	ttl   time.Duration
+	lease time.Duration
+
+	logger *slog.Logger
+}
+
+func (s *service) Begin(ctx context.Context, employeeID, key, requestHash string) (*model.IdempotencyRecord, error) {
+	record, reserved, err := s.repository.Reserve(ctx, employeeID, key, requestHash, s.lease)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_idempotency.ErrNoRecord):
+			//THE RECORD WAS RELEASED BETWEEN THE TWO STATEMENTS, THE FIRST REQUEST IS STILL RACING US
+			return nil, service_idempotency.ErrRequestInProgress
+		default:
+			return nil, service_idempotency.ErrInternal
+		}
+	}
+
+	if reserved {
+		return &record, nil
+	}
+
+	if record.RequestHash != requestHash {
+		return nil, service_idempotency.ErrKeyReused
+	}
+
+	if !record.Completed() {
+		return nil, service_idempotency.ErrRequestInProgress
+	}
+
+	return &record, nil
+}
+
+func (s *service) Complete(ctx context.Context, record model.IdempotencyRecord, statusCode int, headers map[string][]string, body []byte) error {
+	if err := s.repository.Complete(ctx, record, statusCode, headers, body, s.ttl); err != nil {
+		switch {
+		case errors.Is(err, repository_idempotency.ErrLeaseLost):
+			return service_idempotency.ErrLeaseLost
+		default:
+			return service_idempotency.ErrInternal
+		}
+	}
+	return nil
+}
+
+func (s *service) Release(ctx context.Context, record model.IdempotencyRecord) error {
+	if err := s.repository.Release(ctx, record); err != nil {
+		return service_idempotency.ErrInternal
+	}
+	return nil
+}
+
+func (s *service) Purge(ctx context.Context) (int64, error) {
+	deleted, err := s.repository.DeleteExpired(ctx)
+	if err != nil {
+		return 0, service_idempotency.ErrInternal
+	}
+	return deleted, nil
+}
+
+// New keeps a finished response for the ttl. An unfinished request holds its key for the lease only,
+// so a request that crashed halfway does not block retries for the whole ttl.
+func New(repository repository_idempotency.Repository, ttl time.Duration, lease time.Duration, logger *slog.Logger) service_idempotency.Service {
+	return &service{
+		repository: repository,
+		ttl:        ttl,
+		lease:      lease,
+		logger:     logger,
+	}
+}
@@ -0,0 +1,18 @@
+package service_idempotency
+
+import (
+	"avito_intership/internal/model"
+	"context"
+)
+
+type Service interface {
+	//Begin reserves the key for a new request and returns the reserved record with its lease token.
+	//It returns the stored record when the same request already finished
+	Begin(ctx context.Context, employeeID, key, requestHash string) (*model.IdempotencyRecord, error)
+	//Complete stores the response with the headers the handler set, it is replayed until the ttl runs out.
+	//ErrLeaseLost is returned when the request outlived its lease and the key belongs to another request
+	Complete(ctx context.Context, record model.IdempotencyRecord, statusCode int, headers map[string][]string, body []byte) error
+	Release(ctx context.Context, record model.IdempotencyRecord) error
+	//Purge deletes the keys that expired and returns how many there were
+	Purge(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,8 @@
+package service_organization
+
+import "errors"
+
+var (
+	ErrInternal       = errors.New("internal error")
+	ErrNoOrganization = errors.New("no organization")
+)
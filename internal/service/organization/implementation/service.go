@@ -0,0 +1,48 @@
+package service_organization_impl
+
+import (
+	"avito_intership/internal/model"
+	repository_organization "avito_intership/internal/repository/organization"
+	service_organization "avito_intership/internal/service/organization"
+	"context"
+	"errors"
+	"log/slog"
+)
+
+type service struct {
+	repository repository_organization.Repository
+
+	logger *slog.Logger
+}
+
+func (s *service) Create(ctx context.Context, organization model.Organization) (model.Organization, error) {
+	created, err := s.repository.Create(ctx, organization)
+	if err != nil {
+		return model.Organization{}, service_organization.ErrInternal
+	}
+
+	return created, nil
+}
+
+func (s *service) Organization(ctx context.Context, organizationID string) (model.Organization, error) {
+	organization, err := s.repository.Organization(ctx, organizationID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_organization.ErrNoOrganization):
+			return model.Organization{}, service_organization.ErrNoOrganization
+		default:
+			return model.Organization{}, service_organization.ErrInternal
+		}
+	}
+
+	return organization, nil
+}
+
+func New(repository repository_organization.Repository, logger *slog.Logger) service_organization.Service {
+	s := &service{
+		repository: repository,
+		logger:     logger,
+	}
+
+	return s
+}
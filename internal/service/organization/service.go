@@ -0,0 +1,11 @@
+package service_organization
+
+import (
+	"avito_intership/internal/model"
+	"context"
+)
+
+type Service interface {
+	Create(ctx context.Context, organization model.Organization) (model.Organization, error)
+	Organization(ctx context.Context, organizationID string) (model.Organization, error)
+}
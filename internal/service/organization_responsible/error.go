@@ -5,4 +5,8 @@ import "errors"
 var (
 	ErrInternal              = errors.New("internal error")
 	ErrUserHasNoOrganization = errors.New("the user does not have an organization")
+	ErrAlreadyRepresentative = errors.New("the user already represents the organization")
+	ErrNotRepresentative     = errors.New("the user does not represent the organization")
+	ErrEmployeeDeactivated   = errors.New("the employee is deactivated")
+	ErrApprover              = errors.New("the employee approves the bids of an open tender, reassign the approver first")
 )
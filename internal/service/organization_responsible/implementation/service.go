@@ -1,8 +1,13 @@
 package service_organization_resp_impl
 
 import (
+	"avito_intership/internal/model"
+	"avito_intership/internal/quorum"
 	repository_organization_resp "avito_intership/internal/repository/organization_responsible"
+	service_employee "avito_intership/internal/service/employee"
+	service_organization "avito_intership/internal/service/organization"
 	service_organization_resp "avito_intership/internal/service/organization_responsible"
+	"avito_intership/pkg/postgres"
 	"context"
 	"errors"
 	"log/slog"
@@ -10,21 +15,27 @@ import (
 
 type service struct {
 	repository repository_organization_resp.Repository
-	logger     *slog.Logger
+	txManager  postgres.TxManager
+	settler    quorum.Settler
+
+	organizationService service_organization.Service
+	employeeService     service_employee.Service
+
+	logger *slog.Logger
 }
 
-func (s *service) GetOrganizationIDByRepresentative(ctx context.Context, userID string) (organizationID string, err error) {
-	organizationID, err = s.repository.GetOrganizationIDByRepresentative(ctx, userID)
+func (s *service) OrganizationIDsByRepresentative(ctx context.Context, userID string) (organizationIDs []string, err error) {
+	organizationIDs, err = s.repository.OrganizationIDsByRepresentative(ctx, userID)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository_organization_resp.ErrUserHasNoOrganization):
-			return "", service_organization_resp.ErrUserHasNoOrganization
+			return nil, service_organization_resp.ErrUserHasNoOrganization
 		default:
-			return "", service_organization_resp.ErrInternal
+			return nil, service_organization_resp.ErrInternal
 		}
 	}
 
-	return organizationID, nil
+	return organizationIDs, nil
 }
 
 func (s *service) OrganizationRepresentativesAmount(ctx context.Context, organizationID string) (amount int, err error) {
@@ -36,10 +47,128 @@ func (s *service) OrganizationRepresentativesAmount(ctx context.Context, organiz
 	return amount, nil
 }
 
-func New(repository repository_organization_resp.Repository, logger *slog.Logger) service_organization_resp.Service {
+func (s *service) Representatives(ctx context.Context, organizationID string) ([]model.Employee, error) {
+	//CHECK ORGANIZATION EXISTENCE, AN EMPTY LIST IS A VALID ANSWER
+	if _, err := s.organizationService.Organization(ctx, organizationID); err != nil {
+		return nil, err
+	}
+
+	representatives, err := s.repository.Representatives(ctx, organizationID)
+	if err != nil {
+		return nil, service_organization_resp.ErrInternal
+	}
+
+	return representatives, nil
+}
+
+func (s *service) AddRepresentative(ctx context.Context, organizationID string, userID string) error {
+	//CHECK ORGANIZATION EXISTENCE
+	if _, err := s.organizationService.Organization(ctx, organizationID); err != nil {
+		return err
+	}
+
+	//CHECK EMPLOYEE. A DEACTIVATED ONE COULD NOT VOTE BUT WOULD STILL BE LISTED
+	employee, err := s.employeeService.Employee(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if employee.DeactivatedAt != nil {
+		return service_organization_resp.ErrEmployeeDeactivated
+	}
+
+	if err = s.repository.AddRepresentative(ctx, organizationID, userID); err != nil {
+		switch {
+		case errors.Is(err, repository_organization_resp.ErrAlreadyRepresentative):
+			return service_organization_resp.ErrAlreadyRepresentative
+		case errors.Is(err, repository_organization_resp.ErrInvalidReference):
+			//REMOVED CONCURRENTLY
+			return service_employee.ErrNonExistingEmployee
+		default:
+			return service_organization_resp.ErrInternal
+		}
+	}
+
+	return nil
+}
+
+func (s *service) RemoveRepresentative(ctx context.Context, organizationID string, userID string) error {
+	//CHECK ORGANIZATION EXISTENCE
+	if _, err := s.organizationService.Organization(ctx, organizationID); err != nil {
+		return err
+	}
+
+	return s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		if err := s.checkApprover(ctx, []string{organizationID}, userID); err != nil {
+			return err
+		}
+
+		if err := s.repository.RemoveRepresentative(ctx, organizationID, userID); err != nil {
+			switch {
+			case errors.Is(err, repository_organization_resp.ErrNotRepresentative):
+				return service_organization_resp.ErrNotRepresentative
+			default:
+				return service_organization_resp.ErrInternal
+			}
+		}
+
+		//FEWER REPRESENTATIVES MAY ALREADY MAKE UP THE QUORUM OF A PENDING BID
+		return s.settler.Settle(ctx, []string{organizationID})
+	})
+}
+
+func (s *service) DeactivateEmployee(ctx context.Context, userID string) (employee model.Employee, err error) {
+	err = s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		organizationIDs, txErr := s.OrganizationIDsByRepresentative(ctx, userID)
+		if txErr != nil && !errors.Is(txErr, service_organization_resp.ErrUserHasNoOrganization) {
+			return txErr
+		}
+
+		if len(organizationIDs) > 0 {
+			if txErr = s.checkApprover(ctx, organizationIDs, userID); txErr != nil {
+				return txErr
+			}
+		}
+
+		if employee, txErr = s.employeeService.Deactivate(ctx, userID); txErr != nil {
+			return txErr
+		}
+
+		if len(organizationIDs) == 0 {
+			return nil
+		}
+
+		//THE DEACTIVATED EMPLOYEE NO LONGER COUNTS TOWARDS THE QUORUM OF ITS ORGANIZATIONS
+		return s.settler.Settle(ctx, organizationIDs)
+	})
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	return employee, nil
+}
+
+// checkApprover keeps the approver of an open SingleApprover tender in place, without it the tender could never be decided
+func (s *service) checkApprover(ctx context.Context, organizationIDs []string, userID string) error {
+	isApprover, err := s.repository.IsApprover(ctx, organizationIDs, userID)
+	if err != nil {
+		return service_organization_resp.ErrInternal
+	}
+	if isApprover {
+		return service_organization_resp.ErrApprover
+	}
+
+	return nil
+}
+
+func New(repository repository_organization_resp.Repository, txManager postgres.TxManager, organizationService service_organization.Service,
+	employeeService service_employee.Service, settler quorum.Settler, logger *slog.Logger) service_organization_resp.Service {
 	s := &service{
-		repository: repository,
-		logger:     logger,
+		repository:          repository,
+		txManager:           txManager,
+		settler:             settler,
+		organizationService: organizationService,
+		employeeService:     employeeService,
+		logger:              logger,
 	}
 
 	return s
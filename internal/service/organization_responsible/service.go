@@ -1,8 +1,21 @@
 package service_organization_resp
 
-import "context"
+import (
+	"avito_intership/internal/model"
+	"context"
+)
 
 type Service interface {
-	GetOrganizationIDByRepresentative(ctx context.Context, userID string) (organizationID string, err error)
+	//OrganizationIDsByRepresentative lists the organizations the user represents, ErrUserHasNoOrganization when there are none
+	OrganizationIDsByRepresentative(ctx context.Context, userID string) (organizationIDs []string, err error)
+	//OrganizationRepresentativesAmount counts active representatives only, it is the base of the decision quorum
 	OrganizationRepresentativesAmount(ctx context.Context, organizationID string) (amount int, err error)
+	Representatives(ctx context.Context, organizationID string) ([]model.Employee, error)
+	//AddRepresentative makes an active employee a representative, an employee may represent several organizations
+	AddRepresentative(ctx context.Context, organizationID string, userID string) error
+	//RemoveRepresentative settles the pending bids of the organization in the same transaction, fewer representatives
+	//may already make up their quorum
+	RemoveRepresentative(ctx context.Context, organizationID string, userID string) error
+	//DeactivateEmployee deactivates the employee and settles the pending bids of the organizations it represented
+	DeactivateEmployee(ctx context.Context, userID string) (model.Employee, error)
 }
@@ -0,0 +1,7 @@
+package service_outbox
+
+import "errors"
+
+var (
+	ErrInternal = errors.New("internal error")
+)
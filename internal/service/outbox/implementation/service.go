@@ -0,0 +1,90 @@
+package service_outbox_impl
+
+import (
+	"avito_intership/internal/model"
+	repository_outbox "avito_intership/internal/repository/outbox"
+	service_outbox "avito_intership/internal/service/outbox"
+	"avito_intership/pkg/backoff"
+	"avito_intership/pkg/logger"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+type service struct {
+	repository repository_outbox.Repository
+
+	maxAttempts  int
+	retryBackoff time.Duration
+	lease        time.Duration
+
+	logger *slog.Logger
+}
+
+func (s *service) Publish(ctx context.Context, eventType, aggregateType, aggregateID string, organizationIDs []string, payload any) error {
+	l := logger.EndToEndLogging(ctx, s.logger)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		l.Error("Failed to encode event payload", "event_type", eventType, "error", err.Error())
+		return service_outbox.ErrInternal
+	}
+
+	event := model.Event{
+		Type:            eventType,
+		AggregateType:   aggregateType,
+		AggregateID:     aggregateID,
+		OrganizationIDs: organizationIDs,
+		Payload:         data,
+	}
+
+	if err = s.repository.Add(ctx, event); err != nil {
+		return service_outbox.ErrInternal
+	}
+
+	return nil
+}
+
+func (s *service) Claim(ctx context.Context, limit int) ([]model.Event, error) {
+	events, err := s.repository.Claim(ctx, limit, s.maxAttempts, s.lease)
+	if err != nil {
+		return nil, service_outbox.ErrInternal
+	}
+	return events, nil
+}
+
+func (s *service) Delivered(ctx context.Context, event model.Event) error {
+	if err := s.repository.MarkDispatched(ctx, event.ID); err != nil {
+		return service_outbox.ErrInternal
+	}
+	return nil
+}
+
+func (s *service) Failed(ctx context.Context, event model.Event, cause error) error {
+	l := logger.EndToEndLogging(ctx, s.logger)
+
+	attempt := event.Attempts + 1
+	if attempt >= s.maxAttempts {
+		l.Error("Giving up on outbox event",
+			slog.String("event_id", event.ID),
+			slog.String("event_type", event.Type),
+			slog.Int("attempts", attempt),
+			slog.String("error", cause.Error()))
+	}
+
+	if err := s.repository.MarkFailed(ctx, event.ID, backoff.Delay(s.retryBackoff, attempt), cause.Error()); err != nil {
+		return service_outbox.ErrInternal
+	}
+	return nil
+}
+
+func New(repository repository_outbox.Repository, maxAttempts int, retryBackoff time.Duration, lease time.Duration, logger *slog.Logger) service_outbox.Service {
+	return &service{
+		repository:   repository,
+		maxAttempts:  maxAttempts,
+		retryBackoff: retryBackoff,
+		lease:        lease,
+		logger:       logger,
+	}
+}
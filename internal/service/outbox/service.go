@@ -0,0 +1,17 @@
+package service_outbox
+
+import (
+	"avito_intership/internal/model"
+	"context"
+)
+
+type Service interface {
+	//Publish records the event in the outbox. It must be called in the transaction of the state change the event describes.
+	//organizationIDs are the organizations the event is about
+	Publish(ctx context.Context, eventType, aggregateType, aggregateID string, organizationIDs []string, payload any) error
+	//Claim returns the events due for delivery, leased to the caller
+	Claim(ctx context.Context, limit int) ([]model.Event, error)
+	Delivered(ctx context.Context, event model.Event) error
+	//Failed schedules another attempt with exponential backoff. The event is given up after the last attempt
+	Failed(ctx context.Context, event model.Event, cause error) error
+}
@@ -0,0 +1,9 @@
+package service_supplier
+
+import "errors"
+
+var (
+	ErrInternal     = errors.New("internal error")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrNoSupplier   = errors.New("no supplier")
+)
@@ -0,0 +1,87 @@
+package service_supplier_impl
+
+import (
+	"avito_intership/internal/auth"
+	"avito_intership/internal/authz"
+	"avito_intership/internal/model"
+	repository_supplier "avito_intership/internal/repository/supplier"
+	service_feedback "avito_intership/internal/service/feedback"
+	service_supplier "avito_intership/internal/service/supplier"
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// recentReviews is how many of the latest reviews the profile shows next to the stats
+const recentReviews = 5
+
+type service struct {
+	repository repository_supplier.Repository
+	policy     authz.Policy
+
+	feedbackService service_feedback.Service
+
+	logger *slog.Logger
+}
+
+func (s *service) Profile(ctx context.Context, authorID string) (model.SupplierProfile, error) {
+	if _, ok := auth.IdentityFromContext(ctx); !ok {
+		return model.SupplierProfile{}, service_supplier.ErrUnauthorized
+	}
+
+	author, name, err := s.repository.Author(ctx, authorID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_supplier.ErrNoSupplier):
+			return model.SupplierProfile{}, service_supplier.ErrNoSupplier
+		default:
+			return model.SupplierProfile{}, service_supplier.ErrInternal
+		}
+	}
+
+	profile, err := s.repository.BidStats(ctx, author)
+	if err != nil {
+		return model.SupplierProfile{}, service_supplier.ErrInternal
+	}
+	profile.Name = name
+
+	authors := []model.BidAuthor{author}
+
+	profile.Reviews, err = s.feedbackService.Stats(ctx, authors)
+	if err != nil {
+		return model.SupplierProfile{}, service_supplier.ErrInternal
+	}
+
+	profile.RecentReviews = []model.Feedback{}
+	if profile.Reviews.Count == 0 {
+		return profile, nil
+	}
+
+	//CHECK ACCESS. THE STATS ARE PUBLIC, THE REVIEWS THEMSELVES ARE SHOWN ONLY TO THOSE THE REVIEWS ENDPOINT SHOWS THEM TO
+	if err = s.policy.CanReadReviews(ctx, author); err != nil {
+		switch {
+		case errors.Is(err, authz.ErrForbidden):
+			return profile, nil
+		case errors.Is(err, authz.ErrUnauthenticated):
+			return model.SupplierProfile{}, service_supplier.ErrUnauthorized
+		default:
+			return model.SupplierProfile{}, service_supplier.ErrInternal
+		}
+	}
+
+	profile.RecentReviews, _, _, err = s.feedbackService.GetReviews(ctx, authors, model.Page{Limit: recentReviews})
+	if err != nil && !errors.Is(err, service_feedback.ErrNoReviews) {
+		return model.SupplierProfile{}, service_supplier.ErrInternal
+	}
+
+	return profile, nil
+}
+
+func New(repository repository_supplier.Repository, policy authz.Policy, feedbackService service_feedback.Service, logger *slog.Logger) service_supplier.Service {
+	return &service{
+		repository:      repository,
+		policy:          policy,
+		feedbackService: feedbackService,
+		logger:          logger,
+	}
+}
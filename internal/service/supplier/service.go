@@ -0,0 +1,12 @@
+package service_supplier
+
+import (
+	"avito_intership/internal/model"
+	"context"
+)
+
+type Service interface {
+	//Profile returns the track record of the author, a user or an organization, to any signed in employee.
+	//Recent reviews are left empty unless the caller may read the reviews of the author
+	Profile(ctx context.Context, authorID string) (model.SupplierProfile, error)
+}
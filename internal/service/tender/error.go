@@ -3,9 +3,20 @@ package service_tenders
 import "errors"
 
 var (
-	ErrInternal             = errors.New("internal error")
-	ErrNoTenders            = errors.New("no tender")
-	ErrNoSuggestionToUpdate = errors.New("no suggestion to update")
-	ErrInvalidStatus        = errors.New("invalid status")
-	ErrForbidden            = errors.New("forbidden")
+	ErrInternal                  = errors.New("internal error")
+	ErrNoTenders                 = errors.New("no tender")
+	ErrNoSuggestionToUpdate      = errors.New("no suggestion to update")
+	ErrInvalidStatus             = errors.New("invalid status")
+	ErrInvalidStatusTransition   = errors.New("status transition is not allowed")
+	ErrTenderClosed              = errors.New("tender has been closed")
+	ErrInvalidServiceType        = errors.New("invalid service type")
+	ErrInvalidSort               = errors.New("invalid sort")
+	ErrInvalidCursor             = errors.New("cursor pagination requires the newest sort order")
+	ErrVersionConflict           = errors.New("tender has been changed since the expected version")
+	ErrForbidden                 = errors.New("forbidden")
+	ErrUnauthorized              = errors.New("unauthorized")
+	ErrOrganizationRequired      = errors.New("organization to act for is required")
+	ErrInvalidDecisionPolicy     = errors.New("invalid decision policy: FixedApprovals needs requiredApprovals, SingleApprover needs approverId")
+	ErrApproverNotRepresentative = errors.New("approver is not an active representative of the tender organization")
+	ErrTooManyApprovalsRequired  = errors.New("required approvals exceed the active representatives of the tender organization")
 )
@@ -1,91 +1,232 @@
 package service_tenders_impl
 
 import (
+	"avito_intership/internal/auth"
+	"avito_intership/internal/authz"
+	"avito_intership/internal/metrics"
 	"avito_intership/internal/model"
+	"avito_intership/internal/quorum"
 	repository_tenders "avito_intership/internal/repository/tender"
-	service_employee "avito_intership/internal/service/employee"
 	service_organization_resp "avito_intership/internal/service/organization_responsible"
+	service_outbox "avito_intership/internal/service/outbox"
 	service_tenders "avito_intership/internal/service/tender"
+	"avito_intership/pkg/postgres"
 	"context"
 	"errors"
 	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 type service struct {
 	repository repository_tenders.Repository
+	txManager  postgres.TxManager
+	policy     authz.Policy
 
-	employeeService         service_employee.Service
 	organizationRespService service_organization_resp.Service
+	outboxService           service_outbox.Service
+	decisionRules           *quorum.Registry
+	settler                 quorum.Settler
 
 	logger *slog.Logger
 }
 
-func (s *service) organizationIDAndUserIDByUsername(ctx context.Context, username string) (userID string, organizationID string, err error) {
-	userID, err = s.employeeService.IDByUsername(ctx, username)
-	if err != nil {
-		return "", "", err
+var (
+	tenderPublishedStatus = "Published"
+
+	tenderStatuses     = []string{"Created", "Published", "Closed"}
+	tenderServiceTypes = []string{"Construction", "Delivery", "Manufacture"}
+
+	//tenderTransitions lists the statuses a tender may move to from its current status. Closed is final
+	tenderTransitions = map[string][]string{
+		"Created":   {"Published", "Closed"},
+		"Published": {"Closed"},
 	}
+)
 
-	organizationID, err = s.organizationRespService.GetOrganizationIDByRepresentative(ctx, userID)
-	if err != nil {
-		return "", "", err
+// tenderEvents maps the statuses other services are notified about to their events
+var tenderEvents = map[string]string{
+	model.TenderStatusPublished: model.EventTenderPublished,
+	model.TenderStatusClosed:    model.EventTenderClosed,
+}
+
+// publishStatus records the event for the status the tender has moved to. It must run in the transaction of the change
+func (s *service) publishStatus(ctx context.Context, tender model.Tender, organizationID string) error {
+	eventType, ok := tenderEvents[*tender.Status]
+	if !ok {
+		return nil
 	}
 
-	return userID, organizationID, nil
+	payload := model.TenderEventPayload{
+		TenderID:       *tender.ID,
+		OrganizationID: organizationID,
+		Status:         *tender.Status,
+		Version:        *tender.Version,
+	}
+	if tender.ChangedBy != nil {
+		payload.ChangedBy = *tender.ChangedBy
+	}
+
+	if err := s.outboxService.Publish(ctx, eventType, model.AggregateTender, *tender.ID, []string{organizationID}, payload); err != nil {
+		return service_tenders.ErrInternal
+	}
+	return nil
 }
 
-func (s *service) TenderOrganizationID(ctx context.Context, tenderID string) (organizationID string, err error) {
-	organizationID, err = s.repository.TenderOrganizationID(ctx, tenderID)
+// checkDecisionPolicy validates the policy against the registered rules. A designated approver must be
+// an active representative of the tender organization, and no more approvals may be required than it has representatives
+func (s *service) checkDecisionPolicy(ctx context.Context, organizationID string, policy *model.DecisionPolicy) error {
+	if policy == nil {
+		return nil
+	}
+
+	if err := s.decisionRules.Validate(*policy); err != nil {
+		return service_tenders.ErrInvalidDecisionPolicy
+	}
+
+	if policy.RequiredApprovals != nil {
+		amount, err := s.organizationRespService.OrganizationRepresentativesAmount(ctx, organizationID)
+		if err != nil {
+			return service_tenders.ErrInternal
+		}
+
+		if *policy.RequiredApprovals > amount {
+			return service_tenders.ErrTooManyApprovalsRequired
+		}
+	}
+
+	if policy.ApproverID == nil {
+		return nil
+	}
+
+	representatives, err := s.organizationRespService.Representatives(ctx, organizationID)
 	if err != nil {
-		switch {
-		case errors.Is(err, repository_tenders.ErrNoTenders):
-			return "", service_tenders.ErrNoTenders
-		default:
-			return "", service_tenders.ErrInternal
+		return service_tenders.ErrInternal
+	}
+
+	for _, representative := range representatives {
+		if representative.ID == *policy.ApproverID && representative.DeactivatedAt == nil {
+			return nil
 		}
 	}
 
-	return organizationID, nil
+	return service_tenders.ErrApproverNotRepresentative
 }
 
-func (s *service) TenderList(ctx context.Context, serviceTypes []string, limit int, offset int) ([]model.Tender, error) {
-	tenders, err := s.repository.TenderList(ctx, serviceTypes, limit, offset)
+// accessError translates an authorization failure. Tenders hidden from the caller are reported as missing
+func accessError(err error) error {
+	switch {
+	case errors.Is(err, authz.ErrUnauthenticated):
+		return service_tenders.ErrUnauthorized
+	case errors.Is(err, authz.ErrForbidden):
+		return service_tenders.ErrForbidden
+	case errors.Is(err, authz.ErrNotFound):
+		return service_tenders.ErrNoTenders
+	default:
+		return service_tenders.ErrInternal
+	}
+}
+
+func (s *service) TenderList(ctx context.Context, filter model.TenderFilter, page model.Page) ([]model.Tender, model.PageInfo, error) {
+	//VALIDATE FILTER
+	for _, status := range filter.Statuses {
+		if !slices.Contains(tenderStatuses, status) {
+			return nil, model.PageInfo{}, service_tenders.ErrInvalidStatus
+		}
+	}
+
+	for _, serviceType := range filter.ServiceTypes {
+		if !slices.Contains(tenderServiceTypes, serviceType) {
+			return nil, model.PageInfo{}, service_tenders.ErrInvalidServiceType
+		}
+	}
+
+	if filter.Sort != "" && filter.Sort != model.TenderSortNewest && filter.Sort != model.TenderSortName {
+		return nil, model.PageInfo{}, service_tenders.ErrInvalidSort
+	}
+
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	//DRAFTS ARE LISTED FOR THE CALLER ORGANIZATIONS ONLY
+	filter.ViewerOrganizationIDs = nil
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		filter.ViewerOrganizationIDs = identity.OrganizationIDs
+	}
+
+	//KEYSET PAGINATION FOLLOWS THE NEWEST-FIRST ORDER ONLY
+	if page.After != nil && (filter.Sort == model.TenderSortName || (filter.Sort == "" && filter.Query != "")) {
+		return nil, model.PageInfo{}, service_tenders.ErrInvalidCursor
+	}
+
+	tenders, pageInfo, err := s.repository.TenderList(ctx, filter, page)
 	if err != nil {
 		switch {
+		case errors.Is(err, repository_tenders.ErrInvalidCursor):
+			return nil, model.PageInfo{}, service_tenders.ErrInvalidCursor
 		case errors.Is(err, repository_tenders.ErrNoTenders):
-			return nil, service_tenders.ErrNoTenders
+			return nil, model.PageInfo{}, service_tenders.ErrNoTenders
 		default:
-			return nil, service_tenders.ErrInternal
+			return nil, model.PageInfo{}, service_tenders.ErrInternal
 		}
 	}
 
-	return tenders, nil
+	return tenders, pageInfo, nil
 }
 
 func (s *service) Create(ctx context.Context, tender model.Tender) (model.Tender, error) {
+	//TENDERS ARE CREATED ON BEHALF OF ONE OF THE CALLER ORGANIZATIONS, THE CALLER NAMES WHICH
+	if tender.OrganizationID == nil || *tender.OrganizationID == "" {
+		return model.Tender{}, service_tenders.ErrOrganizationRequired
+	}
+
+	if err := s.policy.CanCreateTender(ctx, *tender.OrganizationID); err != nil {
+		return model.Tender{}, accessError(err)
+	}
+
+	if err := s.checkDecisionPolicy(ctx, *tender.OrganizationID, tender.DecisionPolicy); err != nil {
+		return model.Tender{}, err
+	}
+
+	identity, _ := auth.IdentityFromContext(ctx)
+	tender.CreatorUsername = &identity.Username
+	tender.ChangedBy = &identity.EmployeeID
+
 	tender, err := s.repository.Create(ctx, tender)
 	if err != nil {
 		return model.Tender{}, service_tenders.ErrInternal
 	}
+	metrics.TendersCreated.Inc()
 
 	return tender, nil
 }
 
-func (s *service) TendersByUser(ctx context.Context, username string, limit int, offset int) ([]model.Tender, error) {
-	tenders, err := s.repository.TendersByUser(ctx, username, limit, offset)
+func (s *service) TendersByUser(ctx context.Context, page model.Page) ([]model.Tender, model.PageInfo, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, model.PageInfo{}, service_tenders.ErrUnauthorized
+	}
+
+	tenders, pageInfo, err := s.repository.TendersByUser(ctx, identity.Username, page)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository_tenders.ErrNoTenders):
-			return nil, service_tenders.ErrNoTenders
+			return nil, model.PageInfo{}, service_tenders.ErrNoTenders
 		default:
-			return nil, service_tenders.ErrInternal
+			return nil, model.PageInfo{}, service_tenders.ErrInternal
 		}
 	}
 
-	return tenders, nil
+	return tenders, pageInfo, nil
 }
 
 func (s *service) TenderStatus(ctx context.Context, tenderID string) (tenderOrganizationID string, status string, err error) {
+	//CHECK ACCESS
+	if err = s.policy.CanViewTender(ctx, tenderID); err != nil {
+		return "", "", accessError(err)
+	}
+
 	tenderOrganizationID, status, err = s.repository.TenderStatus(ctx, tenderID)
 	if err != nil {
 		switch {
@@ -99,128 +240,402 @@ func (s *service) TenderStatus(ctx context.Context, tenderID string) (tenderOrga
 	return tenderOrganizationID, status, nil
 }
 
-func (s *service) ChangeTenderStatusWithUserCheck(ctx context.Context, tenderID string, username string, status string) (model.Tender, error) {
-	tender, err := s.repository.ChangeTenderStatusWithUserCheck(ctx, tenderID, username, status)
+func (s *service) TenderStatusForUpdate(ctx context.Context, tenderID string) (tenderOrganizationID string, status string, err error) {
+	tenderOrganizationID, status, err = s.repository.TenderStatusForUpdate(ctx, tenderID)
 	if err != nil {
 		switch {
-		case errors.Is(err, repository_tenders.ErrInvalidStatus):
-			return model.Tender{}, service_tenders.ErrInvalidStatus
 		case errors.Is(err, repository_tenders.ErrNoTenders):
-			return model.Tender{}, service_tenders.ErrNoTenders
+			return "", "", service_tenders.ErrNoTenders
 		default:
-			return model.Tender{}, service_tenders.ErrInternal
+			return "", "", service_tenders.ErrInternal
 		}
 	}
 
-	return tender, nil
+	return tenderOrganizationID, status, nil
 }
 
-func (s *service) ChangeTenderStatusForce(ctx context.Context, tenderID string, status string) error {
-	if err := s.repository.ChangeTenderStatusForce(ctx, tenderID, status); err != nil {
+func (s *service) DecisionPolicy(ctx context.Context, tenderID string) (model.DecisionPolicy, error) {
+	policy, err := s.repository.DecisionPolicy(ctx, tenderID)
+	if err != nil {
 		switch {
-		case errors.Is(err, repository_tenders.ErrInvalidStatus):
-			return service_tenders.ErrInvalidStatus
 		case errors.Is(err, repository_tenders.ErrNoTenders):
-			return service_tenders.ErrNoTenders
+			return model.DecisionPolicy{}, service_tenders.ErrNoTenders
 		default:
-			return service_tenders.ErrInternal
+			return model.DecisionPolicy{}, service_tenders.ErrInternal
 		}
 	}
 
-	return nil
+	return policy, nil
 }
 
-func (s *service) Edit(ctx context.Context, tenderID string, username string, tender model.Tender) (model.Tender, error) {
-	//GET INFO BY USERNAME. CHECK IF IT IS A TENDER OWNER AND APPLY SUGGESTIONS
-	userID, err := s.employeeService.IDByUsername(ctx, username)
+func (s *service) ChangeTenderStatusWithUserCheck(ctx context.Context, tenderID string, status string) (tender model.Tender, err error) {
+	err = s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		//LOCK TENDER. ACCESS AND THE TRANSITION ARE CHECKED AGAINST THE STATUS NO ONE ELSE CAN CHANGE MEANWHILE
+		organizationID, currentStatus, txErr := s.TenderStatusForUpdate(ctx, tenderID)
+		if txErr != nil {
+			return txErr
+		}
+
+		//CHECK ACCESS. ANY REPRESENTATIVE OF THE OWNING ORGANIZATION MAY CHANGE THE STATUS, NOT ONLY THE CREATOR
+		if txErr = s.policy.CanManageTender(ctx, tenderID); txErr != nil {
+			return accessError(txErr)
+		}
+
+		if !slices.Contains(tenderStatuses, status) {
+			return service_tenders.ErrInvalidStatus
+		}
+
+		if !slices.Contains(tenderTransitions[currentStatus], status) {
+			return service_tenders.ErrInvalidStatusTransition
+		}
+
+		identity, _ := auth.IdentityFromContext(ctx)
+
+		tender, txErr = s.repository.ChangeTenderStatus(ctx, tenderID, status, identity.EmployeeID)
+		if txErr != nil {
+			switch {
+			case errors.Is(txErr, repository_tenders.ErrInvalidStatus):
+				return service_tenders.ErrInvalidStatus
+			case errors.Is(txErr, repository_tenders.ErrNoTenders):
+				return service_tenders.ErrNoTenders
+			default:
+				return service_tenders.ErrInternal
+			}
+		}
+
+		return s.publishStatus(ctx, tender, organizationID)
+	})
 	if err != nil {
 		return model.Tender{}, err
 	}
 
-	organizationID, err := s.organizationRespService.GetOrganizationIDByRepresentative(ctx, userID)
-	if err != nil {
-		return model.Tender{}, err
+	if status == tenderPublishedStatus {
+		metrics.TendersPublished.Inc()
 	}
 
-	//GET TENDER ORGANIZATION ID
-	tenderOrganizationID, err := s.repository.TenderOrganizationID(ctx, tenderID)
-	if err != nil {
-		switch {
-		case errors.Is(err, repository_tenders.ErrNoTenders):
-			return model.Tender{}, service_tenders.ErrNoTenders
-		default:
-			return model.Tender{}, service_tenders.ErrInternal
+	return tender, nil
+}
+
+func (s *service) ChangeTenderStatusForce(ctx context.Context, tenderID string, status string) error {
+	//THE CHANGE IS ATTRIBUTED TO THE CALLER WHOSE ACTION TRIGGERED IT. WITHOUT ONE, AS AFTER AN ADMIN ACTION, TO NO ONE
+	identity, _ := auth.IdentityFromContext(ctx)
+
+	//JOINS THE CALLER TRANSACTION, IF ANY, SO THE EVENT IS RECORDED ONLY WITH THE CHANGE
+	return s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		//EVEN A FORCED CHANGE FOLLOWS THE TRANSITION TABLE
+		organizationID, currentStatus, err := s.repository.TenderStatus(ctx, tenderID)
+		if err != nil {
+			switch {
+			case errors.Is(err, repository_tenders.ErrNoTenders):
+				return service_tenders.ErrNoTenders
+			default:
+				return service_tenders.ErrInternal
+			}
+		}
+
+		if !slices.Contains(tenderTransitions[currentStatus], status) {
+			return service_tenders.ErrInvalidStatusTransition
+		}
+
+		if err = s.repository.ChangeTenderStatusForce(ctx, tenderID, status, identity.EmployeeID); err != nil {
+			switch {
+			case errors.Is(err, repository_tenders.ErrInvalidStatus):
+				return service_tenders.ErrInvalidStatus
+			case errors.Is(err, repository_tenders.ErrNoTenders):
+				return service_tenders.ErrNoTenders
+			default:
+				return service_tenders.ErrInternal
+			}
+		}
+
+		tender, err := s.repository.TenderByID(ctx, tenderID)
+		if err != nil {
+			return service_tenders.ErrInternal
 		}
+
+		return s.publishStatus(ctx, tender, organizationID)
+	})
+}
+
+func (s *service) Edit(ctx context.Context, tenderID string, tender model.Tender, expectedVersion *int) (model.Tender, error) {
+	//CHECK IF THE CALLER ORGANIZATION IS A TENDER OWNER AND APPLY SUGGESTIONS
+	if err := s.policy.CanManageTender(ctx, tenderID); err != nil {
+		return model.Tender{}, accessError(err)
 	}
 
-	//CHECK ORGANIZATION IDs
-	if organizationID != tenderOrganizationID {
-		return model.Tender{}, service_tenders.ErrForbidden
+	identity, _ := auth.IdentityFromContext(ctx)
+	userID := identity.EmployeeID
+
+	//EDIT. ONLY THE TENDER CONTENT AND THE DECISION POLICY ARE PATCHED, OWNERSHIP AND HISTORY FIELDS ARE SET HERE
+	changeKind := model.ChangeKindEdit
+	patch := model.Tender{
+		Name:           tender.Name,
+		Description:    tender.Description,
+		ServiceType:    tender.ServiceType,
+		DecisionPolicy: tender.DecisionPolicy,
+	}
+	if patch == (model.Tender{}) {
+		return model.Tender{}, service_tenders.ErrNoSuggestionToUpdate
 	}
 
-	//EDIT
-	updatedTender, err := s.repository.Edit(ctx, tenderID, tender)
+	patch.ChangedBy = &userID
+	patch.ChangeKind = &changeKind
+
+	var updatedTender model.Tender
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		//LOCK TENDER, SO A DECISION CAN NOT BE SETTLED BY THE POLICY THAT IS BEING REPLACED
+		organizationID, status, txErr := s.TenderStatusForUpdate(ctx, tenderID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if patch.DecisionPolicy != nil {
+			if status == model.TenderStatusClosed {
+				return service_tenders.ErrTenderClosed
+			}
+
+			if txErr = s.checkDecisionPolicy(ctx, organizationID, patch.DecisionPolicy); txErr != nil {
+				return txErr
+			}
+		}
+
+		updatedTender, txErr = s.repository.Edit(ctx, tenderID, patch, expectedVersion)
+		if txErr != nil {
+			switch {
+			case errors.Is(txErr, repository_tenders.ErrNoSuggestionToUpdate):
+				return service_tenders.ErrNoSuggestionToUpdate
+			case errors.Is(txErr, repository_tenders.ErrNoTenders):
+				return service_tenders.ErrNoTenders
+			case errors.Is(txErr, repository_tenders.ErrVersionConflict):
+				//THE CALLER GETS THE CURRENT STATE TO REAPPLY ITS CHANGES ON
+				if updatedTender, txErr = s.repository.TenderByID(ctx, tenderID); txErr != nil {
+					return service_tenders.ErrInternal
+				}
+				return service_tenders.ErrVersionConflict
+			default:
+				return service_tenders.ErrInternal
+			}
+		}
+
+		if patch.DecisionPolicy == nil || status != model.TenderStatusPublished {
+			return nil
+		}
+
+		//THE NEW POLICY MAY ALREADY BE MET BY THE VOTES CAST ON A PENDING BID
+		if txErr = s.settler.Settle(ctx, []string{organizationID}); txErr != nil {
+			return txErr
+		}
+
+		if updatedTender, txErr = s.repository.TenderByID(ctx, tenderID); txErr != nil {
+			return service_tenders.ErrInternal
+		}
+		return nil
+	})
 	if err != nil {
-		switch {
-		case errors.Is(err, repository_tenders.ErrNoSuggestionToUpdate):
-			return model.Tender{}, service_tenders.ErrNoSuggestionToUpdate
-		case errors.Is(err, repository_tenders.ErrNoTenders):
-			return model.Tender{}, service_tenders.ErrNoTenders
-		default:
-			return model.Tender{}, service_tenders.ErrInternal
+		if errors.Is(err, service_tenders.ErrVersionConflict) {
+			return updatedTender, err
 		}
+		return model.Tender{}, err
 	}
 
 	return updatedTender, nil
 }
 
-func (s *service) RollbackVersion(ctx context.Context, tenderID string, username string, version int) (model.Tender, error) {
-	_, organizationID, err := s.organizationIDAndUserIDByUsername(ctx, username)
+func (s *service) RollbackVersion(ctx context.Context, tenderID string, version int) (model.Tender, error) {
+	//CHECK ACCESS
+	if err := s.policy.CanManageTender(ctx, tenderID); err != nil {
+		return model.Tender{}, accessError(err)
+	}
+
+	identity, _ := auth.IdentityFromContext(ctx)
+	userID := identity.EmployeeID
+
+	var tender model.Tender
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		//A ROLLBACK RESTORES THE STATUS AS WELL, SO IT MUST NOT BYPASS THE TRANSITION TABLE
+		organizationID, currentStatus, txErr := s.TenderStatusForUpdate(ctx, tenderID)
+		if txErr != nil {
+			return txErr
+		}
+
+		target, txErr := s.version(ctx, tenderID, version)
+		if txErr != nil {
+			return txErr
+		}
+
+		if *target.Tender.Status != currentStatus && !slices.Contains(tenderTransitions[currentStatus], *target.Tender.Status) {
+			return service_tenders.ErrInvalidStatusTransition
+		}
+
+		//THE RESTORED POLICY IS CHECKED THE SAME WAY AN EDITED ONE IS, THE REPRESENTATIVES MAY HAVE CHANGED SINCE
+		currentPolicy, txErr := s.DecisionPolicy(ctx, tenderID)
+		if txErr != nil {
+			return txErr
+		}
+		policyChanged := !samePolicy(&currentPolicy, target.Tender.DecisionPolicy)
+		if policyChanged {
+			if currentStatus == model.TenderStatusClosed {
+				return service_tenders.ErrTenderClosed
+			}
+
+			if txErr = s.checkDecisionPolicy(ctx, organizationID, target.Tender.DecisionPolicy); txErr != nil {
+				return txErr
+			}
+		}
+
+		tender, txErr = s.repository.RollbackVersion(ctx, tenderID, version, userID)
+		if txErr != nil {
+			switch {
+			case errors.Is(txErr, repository_tenders.ErrNoTenders):
+				return service_tenders.ErrNoTenders
+			default:
+				return service_tenders.ErrInternal
+			}
+		}
+
+		if *tender.Status != currentStatus {
+			if txErr = s.publishStatus(ctx, tender, organizationID); txErr != nil {
+				return txErr
+			}
+		}
+
+		if !policyChanged || *tender.Status != model.TenderStatusPublished {
+			return nil
+		}
+
+		//THE RESTORED POLICY MAY ALREADY BE MET BY THE VOTES CAST ON A PENDING BID
+		if txErr = s.settler.Settle(ctx, []string{organizationID}); txErr != nil {
+			return txErr
+		}
+
+		if tender, txErr = s.repository.TenderByID(ctx, tenderID); txErr != nil {
+			return service_tenders.ErrInternal
+		}
+		return nil
+	})
 	if err != nil {
 		return model.Tender{}, err
 	}
 
-	tenderOrganizationID, err := s.repository.TenderOrganizationID(ctx, tenderID)
+	return tender, nil
+}
+
+func (s *service) Versions(ctx context.Context, tenderID string) ([]model.TenderVersion, error) {
+	//CHECK ACCESS
+	if err := s.policy.CanViewTender(ctx, tenderID); err != nil {
+		return nil, accessError(err)
+	}
+
+	versions, err := s.repository.Versions(ctx, tenderID)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository_tenders.ErrNoTenders):
-			return model.Tender{}, service_tenders.ErrNoTenders
+			return nil, service_tenders.ErrNoTenders
 		default:
-			return model.Tender{}, service_tenders.ErrInternal
+			return nil, service_tenders.ErrInternal
 		}
 	}
 
-	if organizationID != tenderOrganizationID {
-		return model.Tender{}, service_tenders.ErrForbidden
+	return versions, nil
+}
+
+func (s *service) Version(ctx context.Context, tenderID string, version int) (model.TenderVersion, error) {
+	//CHECK ACCESS
+	if err := s.policy.CanViewTender(ctx, tenderID); err != nil {
+		return model.TenderVersion{}, accessError(err)
 	}
 
-	tender, err := s.repository.RollbackVersion(ctx, tenderID, version)
+	return s.version(ctx, tenderID, version)
+}
+
+func (s *service) version(ctx context.Context, tenderID string, version int) (model.TenderVersion, error) {
+	tenderVersion, err := s.repository.Version(ctx, tenderID, version)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository_tenders.ErrNoTenders):
-			return model.Tender{}, service_tenders.ErrNoTenders
+			return model.TenderVersion{}, service_tenders.ErrNoTenders
 		default:
-			return model.Tender{}, service_tenders.ErrInternal
+			return model.TenderVersion{}, service_tenders.ErrInternal
 		}
 	}
 
-	return tender, nil
+	return tenderVersion, nil
 }
 
-func (s *service) ConfirmTenderCreator(ctx context.Context, tenderID string, userOrganizationID string) (exists bool, err error) {
-	exists, err = s.repository.ConfirmTenderCreator(ctx, tenderID, userOrganizationID)
+func (s *service) Diff(ctx context.Context, tenderID string, from int, to int) (model.VersionDiff, error) {
+	//CHECK ACCESS
+	if err := s.policy.CanViewTender(ctx, tenderID); err != nil {
+		return model.VersionDiff{}, accessError(err)
+	}
+
+	fromVersion, err := s.version(ctx, tenderID, from)
 	if err != nil {
-		return false, service_tenders.ErrInternal
+		return model.VersionDiff{}, err
+	}
+
+	toVersion, err := s.version(ctx, tenderID, to)
+	if err != nil {
+		return model.VersionDiff{}, err
+	}
+
+	diff := model.VersionDiff{
+		From:       from,
+		To:         to,
+		ChangedAt:  toVersion.ChangedAt,
+		ChangedBy:  toVersion.Tender.ChangedBy,
+		ChangeKind: toVersion.Tender.ChangeKind,
+		Changes:    make([]model.FieldChange, 0),
 	}
+	diff.Compare(model.FieldName, fromVersion.Tender.Name, toVersion.Tender.Name)
+	diff.Compare(model.FieldDescription, fromVersion.Tender.Description, toVersion.Tender.Description)
+	diff.Compare(model.FieldServiceType, fromVersion.Tender.ServiceType, toVersion.Tender.ServiceType)
+	diff.Compare(model.FieldStatus, fromVersion.Tender.Status, toVersion.Tender.Status)
+
+	fromPolicy, toPolicy := policyFields(fromVersion.Tender.DecisionPolicy), policyFields(toVersion.Tender.DecisionPolicy)
+	for _, field := range []string{model.FieldDecisionPolicyType, model.FieldRequiredApprovals, model.FieldApproverID, model.FieldRejectionVeto} {
+		diff.Compare(field, fromPolicy[field], toPolicy[field])
+	}
+
+	return diff, nil
+}
+
+// policyFields renders the decision policy as the diff fields it is compared by
+func policyFields(policy *model.DecisionPolicy) map[string]*string {
+	if policy == nil {
+		return nil
+	}
+
+	rejectionVeto := strconv.FormatBool(policy.RejectionVeto)
+	fields := map[string]*string{
+		model.FieldDecisionPolicyType: &policy.Type,
+		model.FieldApproverID:         policy.ApproverID,
+		model.FieldRejectionVeto:      &rejectionVeto,
+	}
+	if policy.RequiredApprovals != nil {
+		requiredApprovals := strconv.Itoa(*policy.RequiredApprovals)
+		fields[model.FieldRequiredApprovals] = &requiredApprovals
+	}
+
+	return fields
+}
 
-	return exists, nil
+// samePolicy reports whether the policies decide bids the same way
+func samePolicy(a, b *model.DecisionPolicy) bool {
+	return maps.EqualFunc(policyFields(a), policyFields(b), func(x, y *string) bool {
+		return x == nil && y == nil || x != nil && y != nil && *x == *y
+	})
 }
 
-func New(repository repository_tenders.Repository, employeeService service_employee.Service, organizationRespService service_organization_resp.Service, logger *slog.Logger) service_tenders.Service {
+func New(repository repository_tenders.Repository, txManager postgres.TxManager, policy authz.Policy, organizationRespService service_organization_resp.Service,
+	outboxService service_outbox.Service, decisionRules *quorum.Registry, settler quorum.Settler, logger *slog.Logger) service_tenders.Service {
 	s := &service{
 		repository:              repository,
-		employeeService:         employeeService,
+		txManager:               txManager,
+		policy:                  policy,
 		organizationRespService: organizationRespService,
+		outboxService:           outboxService,
+		decisionRules:           decisionRules,
+		settler:                 settler,
 		logger:                  logger,
 	}
 
@@ -6,14 +6,27 @@ import (
 )
 
 type Service interface {
-	TenderOrganizationID(ctx context.Context, tenderID string) (organizationID string, err error)
-	TenderList(ctx context.Context, serviceTypes []string, limit int, offset int) ([]model.Tender, error)
+	//TenderList searches tenders by a free-text query and filters. Drafts are listed for the caller organizations only
+	TenderList(ctx context.Context, filter model.TenderFilter, page model.Page) ([]model.Tender, model.PageInfo, error)
+	//Create creates a tender on behalf of the caller organization named in it, the default decision policy applies when none is given
 	Create(ctx context.Context, tender model.Tender) (model.Tender, error)
-	TendersByUser(ctx context.Context, username string, limit int, offset int) ([]model.Tender, error)
+	//TendersByUser returns tenders created by the caller
+	TendersByUser(ctx context.Context, page model.Page) ([]model.Tender, model.PageInfo, error)
+	//TenderStatus returns the tender status, drafts of other organizations are reported as missing
 	TenderStatus(ctx context.Context, tenderID string) (tenderOrganizationID string, status string, err error)
-	ChangeTenderStatusWithUserCheck(ctx context.Context, tenderID string, username string, status string) (model.Tender, error)
+	//TenderStatusForUpdate locks the tender row, so it must be called inside a transaction
+	TenderStatusForUpdate(ctx context.Context, tenderID string) (tenderOrganizationID string, status string, err error)
+	//DecisionPolicy returns the policy the bids of the tender are decided by, the caller is not checked
+	DecisionPolicy(ctx context.Context, tenderID string) (model.DecisionPolicy, error)
+	ChangeTenderStatusWithUserCheck(ctx context.Context, tenderID string, status string) (model.Tender, error)
+	//ChangeTenderStatusForce skips the access check, the change is attributed to the caller if there is one
 	ChangeTenderStatusForce(ctx context.Context, tenderID string, status string) error
-	Edit(ctx context.Context, tenderID string, username string, tender model.Tender) (model.Tender, error)
-	RollbackVersion(ctx context.Context, tenderID string, username string, version int) (model.Tender, error)
-	ConfirmTenderCreator(ctx context.Context, tenderID string, userOrganizationID string) (exists bool, err error)
+	//Edit patches the tender, a decision policy given replaces the current one. When expectedVersion is set and the tender has moved on, the current tender is returned with ErrVersionConflict
+	Edit(ctx context.Context, tenderID string, tender model.Tender, expectedVersion *int) (model.Tender, error)
+	RollbackVersion(ctx context.Context, tenderID string, version int) (model.Tender, error)
+	//Versions returns the version history of the tender, oldest first
+	Versions(ctx context.Context, tenderID string) ([]model.TenderVersion, error)
+	Version(ctx context.Context, tenderID string, version int) (model.TenderVersion, error)
+	//Diff returns the fields changed between two versions of the tender
+	Diff(ctx context.Context, tenderID string, from int, to int) (model.VersionDiff, error)
 }
@@ -0,0 +1,14 @@
+package service_webhook
+
+import "errors"
+
+var (
+	ErrInternal             = errors.New("internal error")
+	ErrUnauthorized         = errors.New("unauthorized")
+	ErrNoOrganization       = errors.New("webhooks are managed by organization representatives only")
+	ErrNoSubscription       = errors.New("no webhook subscription")
+	ErrNoDelivery           = errors.New("no webhook delivery")
+	ErrNoSuggestionToUpdate = errors.New("no suggestion to update")
+	ErrOrganizationRequired = errors.New("organization to act for is required, the caller represents several")
+	ErrForbidden            = errors.New("forbidden")
+)
@@ -0,0 +1,300 @@
+package service_webhook_impl
+
+import (
+	"avito_intership/internal/auth"
+	"avito_intership/internal/model"
+	repository_webhook "avito_intership/internal/repository/webhook"
+	service_webhook "avito_intership/internal/service/webhook"
+	"avito_intership/pkg/backoff"
+	"avito_intership/pkg/logger"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+const (
+	secretPrefix = "whsec_"
+	secretBytes  = 32
+)
+
+type service struct {
+	repository repository_webhook.Repository
+
+	maxAttempts  int
+	retryBackoff time.Duration
+	lease        time.Duration
+	disableAfter int
+
+	logger *slog.Logger
+}
+
+// organizations returns the organizations of the caller, webhooks belong to organizations only
+func organizations(ctx context.Context) ([]string, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, service_webhook.ErrUnauthorized
+	}
+
+	if len(identity.OrganizationIDs) == 0 {
+		return nil, service_webhook.ErrNoOrganization
+	}
+
+	return identity.OrganizationIDs, nil
+}
+
+func subscriptionError(err error) error {
+	switch {
+	case errors.Is(err, repository_webhook.ErrNoSubscription):
+		return service_webhook.ErrNoSubscription
+	default:
+		return service_webhook.ErrInternal
+	}
+}
+
+func newSecret() (string, error) {
+	raw := make([]byte, secretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(raw), nil
+}
+
+func (s *service) Create(ctx context.Context, subscription model.WebhookSubscription) (model.WebhookSubscription, error) {
+	l := logger.EndToEndLogging(ctx, s.logger)
+
+	organizationIDs, err := organizations(ctx)
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+
+	//THE ORGANIZATION MAY BE LEFT OUT BY A CALLER THAT REPRESENTS ONLY ONE
+	switch {
+	case subscription.OrganizationID == "" && len(organizationIDs) > 1:
+		return model.WebhookSubscription{}, service_webhook.ErrOrganizationRequired
+	case subscription.OrganizationID == "":
+		subscription.OrganizationID = organizationIDs[0]
+	case !slices.Contains(organizationIDs, subscription.OrganizationID):
+		return model.WebhookSubscription{}, service_webhook.ErrForbidden
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		l.Error("Failed to generate webhook secret", "error", err.Error())
+		return model.WebhookSubscription{}, service_webhook.ErrInternal
+	}
+
+	subscription.Secret = secret
+	if subscription.EventTypes == nil {
+		subscription.EventTypes = []string{}
+	}
+
+	created, err := s.repository.Create(ctx, subscription)
+	if err != nil {
+		return model.WebhookSubscription{}, service_webhook.ErrInternal
+	}
+
+	return created, nil
+}
+
+func (s *service) Subscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	organizationIDs, err := organizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.repository.Subscriptions(ctx, organizationIDs)
+	if err != nil {
+		return nil, subscriptionError(err)
+	}
+
+	return subscriptions, nil
+}
+
+func (s *service) Subscription(ctx context.Context, subscriptionID string) (model.WebhookSubscription, error) {
+	organizationIDs, err := organizations(ctx)
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+
+	//SUBSCRIPTIONS OF ORGANIZATIONS THE CALLER DOES NOT REPRESENT ARE REPORTED AS MISSING
+	subscription, err := s.repository.Subscription(ctx, organizationIDs, subscriptionID)
+	if err != nil {
+		return model.WebhookSubscription{}, subscriptionError(err)
+	}
+
+	return subscription, nil
+}
+
+func (s *service) Update(ctx context.Context, subscriptionID string, patch model.WebhookSubscriptionPatch) (model.WebhookSubscription, error) {
+	organizationIDs, err := organizations(ctx)
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+
+	if patch == (model.WebhookSubscriptionPatch{}) {
+		return model.WebhookSubscription{}, service_webhook.ErrNoSuggestionToUpdate
+	}
+
+	subscription, err := s.repository.Update(ctx, organizationIDs, subscriptionID, patch)
+	if err != nil {
+		return model.WebhookSubscription{}, subscriptionError(err)
+	}
+
+	return subscription, nil
+}
+
+func (s *service) Delete(ctx context.Context, subscriptionID string) error {
+	organizationIDs, err := organizations(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repository.Delete(ctx, organizationIDs, subscriptionID); err != nil {
+		return subscriptionError(err)
+	}
+
+	return nil
+}
+
+func (s *service) RotateSecret(ctx context.Context, subscriptionID string) (model.WebhookSubscription, error) {
+	l := logger.EndToEndLogging(ctx, s.logger)
+
+	organizationIDs, err := organizations(ctx)
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		l.Error("Failed to generate webhook secret", "error", err.Error())
+		return model.WebhookSubscription{}, service_webhook.ErrInternal
+	}
+
+	subscription, err := s.repository.RotateSecret(ctx, organizationIDs, subscriptionID, secret)
+	if err != nil {
+		return model.WebhookSubscription{}, subscriptionError(err)
+	}
+
+	return subscription, nil
+}
+
+func (s *service) Deliveries(ctx context.Context, subscriptionID string, status string, page model.Page) ([]model.WebhookDelivery, model.PageInfo, error) {
+	//CHECK THE SUBSCRIPTION BELONGS TO ONE OF THE CALLER ORGANIZATIONS
+	if _, err := s.Subscription(ctx, subscriptionID); err != nil {
+		return nil, model.PageInfo{}, err
+	}
+
+	deliveries, pageInfo, err := s.repository.Deliveries(ctx, subscriptionID, status, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_webhook.ErrNoDelivery):
+			return nil, model.PageInfo{}, service_webhook.ErrNoDelivery
+		default:
+			return nil, model.PageInfo{}, service_webhook.ErrInternal
+		}
+	}
+
+	return deliveries, pageInfo, nil
+}
+
+func (s *service) Replay(ctx context.Context, subscriptionID, deliveryID string) (model.WebhookDelivery, error) {
+	//CHECK THE SUBSCRIPTION BELONGS TO ONE OF THE CALLER ORGANIZATIONS
+	if _, err := s.Subscription(ctx, subscriptionID); err != nil {
+		return model.WebhookDelivery{}, err
+	}
+
+	delivery, err := s.repository.Replay(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_webhook.ErrNoDelivery):
+			return model.WebhookDelivery{}, service_webhook.ErrNoDelivery
+		default:
+			return model.WebhookDelivery{}, service_webhook.ErrInternal
+		}
+	}
+
+	return delivery, nil
+}
+
+func (s *service) Enqueue(ctx context.Context, event model.Event) error {
+	if len(event.OrganizationIDs) == 0 {
+		return nil
+	}
+
+	if _, err := s.repository.Enqueue(ctx, event); err != nil {
+		return service_webhook.ErrInternal
+	}
+	return nil
+}
+
+func (s *service) Claim(ctx context.Context, limit int) ([]model.WebhookDispatch, error) {
+	dispatches, err := s.repository.Claim(ctx, limit, s.lease)
+	if err != nil {
+		return nil, service_webhook.ErrInternal
+	}
+	return dispatches, nil
+}
+
+func (s *service) Delivered(ctx context.Context, dispatch model.WebhookDispatch, statusCode int) error {
+	if err := s.repository.MarkDelivered(ctx, dispatch.Delivery.ID, statusCode); err != nil {
+		return service_webhook.ErrInternal
+	}
+
+	if err := s.repository.ResetFailures(ctx, dispatch.Delivery.SubscriptionID); err != nil {
+		return service_webhook.ErrInternal
+	}
+
+	return nil
+}
+
+func (s *service) Failed(ctx context.Context, dispatch model.WebhookDispatch, statusCode int, cause error, retryable bool) error {
+	l := logger.EndToEndLogging(ctx, s.logger)
+
+	attempt := dispatch.Delivery.Attempts + 1
+	giveUp := !retryable || attempt >= s.maxAttempts
+	if giveUp {
+		l.Warn("Giving up on webhook delivery",
+			slog.String("delivery_id", dispatch.Delivery.ID),
+			slog.String("subscription_id", dispatch.Delivery.SubscriptionID),
+			slog.Int("attempts", attempt),
+			slog.String("error", cause.Error()))
+	}
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+
+	if err := s.repository.MarkFailed(ctx, dispatch.Delivery.ID, code, cause.Error(), backoff.Delay(s.retryBackoff, attempt), giveUp); err != nil {
+		return service_webhook.ErrInternal
+	}
+
+	disabled, err := s.repository.CountFailure(ctx, dispatch.Delivery.SubscriptionID, s.disableAfter)
+	if err != nil && !errors.Is(err, repository_webhook.ErrNoSubscription) {
+		return service_webhook.ErrInternal
+	}
+
+	if disabled {
+		l.Warn("Deactivating webhook subscription after consecutive failures",
+			slog.String("subscription_id", dispatch.Delivery.SubscriptionID),
+			slog.Int("failures", s.disableAfter))
+	}
+
+	return nil
+}
+
+func New(repository repository_webhook.Repository, maxAttempts int, retryBackoff time.Duration, lease time.Duration, disableAfter int,
+	logger *slog.Logger) service_webhook.Service {
+	return &service{
+		repository:   repository,
+		maxAttempts:  maxAttempts,
+		retryBackoff: retryBackoff,
+		lease:        lease,
+		disableAfter: disableAfter,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,32 @@
+package service_webhook
+
+import (
+	"avito_intership/internal/model"
+	"context"
+)
+
+type Service interface {
+	//Create registers an endpoint for one of the caller organizations, it must be named when the caller represents several.
+	//The generated secret is returned this time only
+	Create(ctx context.Context, subscription model.WebhookSubscription) (model.WebhookSubscription, error)
+	//Subscriptions lists the subscriptions of every organization the caller represents
+	Subscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	Subscription(ctx context.Context, subscriptionID string) (model.WebhookSubscription, error)
+	Update(ctx context.Context, subscriptionID string, patch model.WebhookSubscriptionPatch) (model.WebhookSubscription, error)
+	Delete(ctx context.Context, subscriptionID string) error
+	//RotateSecret replaces the signing secret at once and returns the new one
+	RotateSecret(ctx context.Context, subscriptionID string) (model.WebhookSubscription, error)
+	//Deliveries returns the delivery log of the subscription, newest first
+	Deliveries(ctx context.Context, subscriptionID string, status string, page model.Page) ([]model.WebhookDelivery, model.PageInfo, error)
+	//Replay sends the delivery again, whatever its outcome was
+	Replay(ctx context.Context, subscriptionID, deliveryID string) (model.WebhookDelivery, error)
+
+	//Enqueue queues the event for the subscriptions of the organizations it is about
+	Enqueue(ctx context.Context, event model.Event) error
+	//Claim returns the deliveries due to be sent, leased to the caller
+	Claim(ctx context.Context, limit int) ([]model.WebhookDispatch, error)
+	Delivered(ctx context.Context, dispatch model.WebhookDispatch, statusCode int) error
+	//Failed schedules another attempt with exponential backoff. Non-retryable failures and the last attempt give the delivery up,
+	//an endpoint failing too many times in a row is deactivated
+	Failed(ctx context.Context, dispatch model.WebhookDispatch, statusCode int, cause error, retryable bool) error
+}
@@ -2,8 +2,11 @@ package validator
 
 import (
 	"errors"
+	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/hashicorp/go-multierror"
+	"reflect"
+	"strings"
 )
 
 const (
@@ -14,28 +17,87 @@ var (
 	ErrInvalidAuthorType = errors.New("invalid author type. possible accepted value: Organization, User")
 )
 
+// FieldError tells which request field was rejected and why. Field is the json name of the field.
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
 type Validate struct {
 	validate *validator.Validate
 }
 
-func (v *Validate) Validate(model any) (resErr error) {
-	err := v.validate.Struct(model)
-	if err != nil {
-		var validationErrors validator.ValidationErrors
-		if errors.As(err, &validationErrors) {
-			for _, validationErr := range validationErrors {
-				switch validationErr.Tag() {
-				case AuthorTypeTag:
-					resErr = multierror.Append(resErr, ErrInvalidAuthorType)
-				default:
-					resErr = multierror.Append(resErr, err)
-				}
+// Validate checks the struct tags of model and returns a multierror with one FieldError per offending field.
+func (v *Validate) Validate(model any) error {
+	return aggregate(v.validate.Struct(model))
+}
+
+// Var checks a single value, e.g. a query parameter, and reports it under the given field name.
+func (v *Validate) Var(field string, value any, tag string) error {
+	err := aggregate(v.validate.Var(value, tag))
+
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		for i := range merr.Errors {
+			if fieldErr, ok := merr.Errors[i].(FieldError); ok {
+				fieldErr.Field = field
+				merr.Errors[i] = fieldErr
 			}
 		}
-		return resErr
 	}
 
-	return nil
+	return err
+}
+
+func aggregate(err error) (resErr error) {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	for _, validationErr := range validationErrors {
+		resErr = multierror.Append(resErr, FieldError{
+			Field:  validationErr.Field(),
+			Reason: reason(validationErr),
+		})
+	}
+
+	return resErr
+}
+
+func reason(validationErr validator.FieldError) string {
+	switch validationErr.Tag() {
+	case AuthorTypeTag:
+		return ErrInvalidAuthorType.Error()
+	case "required":
+		return "is required"
+	case "min":
+		if validationErr.Kind() != reflect.String {
+			return fmt.Sprintf("must be at least %s", validationErr.Param())
+		}
+		return fmt.Sprintf("must be at least %s characters long", validationErr.Param())
+	case "max":
+		if validationErr.Kind() != reflect.String {
+			return fmt.Sprintf("must be at most %s", validationErr.Param())
+		}
+		return fmt.Sprintf("must be at most %s characters long", validationErr.Param())
+	case "uuid":
+		return "must be a valid uuid"
+	case "http_url":
+		return "must be an absolute http(s) url"
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(validationErr.Param()), ", ")
+	default:
+		return fmt.Sprintf("failed on the %q rule", validationErr.Tag())
+	}
 }
 
 func (v *Validate) RegisterTag(tag string, fn validator.Func) error {
@@ -46,7 +108,18 @@ func (v *Validate) RegisterTag(tag string, fn validator.Func) error {
 }
 
 func New() *Validate {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	//REPORT FIELDS UNDER THE NAMES CLIENTS SEND THEM WITH
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
 	return &Validate{
-		validate: validator.New(),
+		validate: validate,
 	}
 }
@@ -1,3 +1,5 @@
+DROP TRIGGER trg_update_tender_version ON tender;
+DROP FUNCTION update_tender_version();
 DROP TABLE IF EXISTS tender_history;
 DROP TABLE IF EXISTS tender;
 DROP TYPE service_type;
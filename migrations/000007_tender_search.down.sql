@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tender_organization_id;
+DROP INDEX IF EXISTS idx_tender_created_at;
+DROP INDEX IF EXISTS idx_tender_search_vector;
+
+ALTER TABLE tender DROP COLUMN IF EXISTS search_vector;
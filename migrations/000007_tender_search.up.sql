@@ -0,0 +1,9 @@
+ALTER TABLE tender ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX idx_tender_search_vector ON tender USING GIN (search_vector);
+CREATE INDEX idx_tender_created_at ON tender (created_at DESC, id DESC);
+CREATE INDEX idx_tender_organization_id ON tender (organization_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_review_author_created_at;
+DROP INDEX IF EXISTS idx_bid_author_created_at;
+DROP INDEX IF EXISTS idx_bid_tender_created_at;
+DROP INDEX IF EXISTS idx_tender_creator_created_at;
@@ -0,0 +1,4 @@
+CREATE INDEX idx_tender_creator_created_at ON tender (creator_username, created_at DESC, id DESC);
+CREATE INDEX idx_bid_tender_created_at ON bid (tender_id, created_at DESC, id DESC);
+CREATE INDEX idx_bid_author_created_at ON bid (author_id, created_at DESC, id DESC);
+CREATE INDEX idx_review_author_created_at ON review (author_username, created_at DESC, id DESC);
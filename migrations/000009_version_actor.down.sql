@@ -0,0 +1,30 @@
+CREATE OR REPLACE FUNCTION update_bid_version()
+RETURNS TRIGGER AS $$
+    BEGIN
+        INSERT INTO bid_history (id, name, description, status, tender_id, author_type, author_id, version, created_at, updated_at)
+        VALUES (OLD.id, OLD.name, OLD.description, OLD.status, OLD.tender_id, OLD.author_type, OLD.author_id, OLD.version, OLD.created_at, CURRENT_TIMESTAMP);
+
+        NEW.version := OLD.version + 1;
+
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION update_tender_version()
+RETURNS TRIGGER AS $$
+    BEGIN
+        INSERT INTO tender_history (id, name, description, service_type, status, organization_id, creator_username, version, created_at, updated_at)
+        VALUES (OLD.id, OLD.name, OLD.description, OLD.service_type, OLD.status, OLD.organization_id, OLD.creator_username, OLD.version, OLD.created_at, CURRENT_TIMESTAMP);
+
+        NEW.version := OLD.version + 1;
+
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE bid_history DROP COLUMN changed_by, DROP COLUMN change_kind, DROP COLUMN changed_at;
+ALTER TABLE bid DROP COLUMN changed_by, DROP COLUMN change_kind, DROP COLUMN changed_at;
+ALTER TABLE tender_history DROP COLUMN changed_by, DROP COLUMN change_kind, DROP COLUMN changed_at;
+ALTER TABLE tender DROP COLUMN changed_by, DROP COLUMN change_kind, DROP COLUMN changed_at;
+
+DROP TYPE change_kind;
@@ -0,0 +1,54 @@
+CREATE TYPE change_kind AS ENUM (
+    'Create',
+    'Edit',
+    'StatusChange',
+    'Rollback'
+);
+
+ALTER TABLE tender
+    ADD COLUMN changed_by  UUID REFERENCES employee (id) ON DELETE SET NULL,
+    ADD COLUMN change_kind change_kind,
+    ADD COLUMN changed_at  TIMESTAMP;
+
+ALTER TABLE tender_history
+    ADD COLUMN changed_by  UUID,
+    ADD COLUMN change_kind change_kind,
+    ADD COLUMN changed_at  TIMESTAMP;
+
+ALTER TABLE bid
+    ADD COLUMN changed_by  UUID REFERENCES employee (id) ON DELETE SET NULL,
+    ADD COLUMN change_kind change_kind,
+    ADD COLUMN changed_at  TIMESTAMP;
+
+ALTER TABLE bid_history
+    ADD COLUMN changed_by  UUID,
+    ADD COLUMN change_kind change_kind,
+    ADD COLUMN changed_at  TIMESTAMP;
+
+-- Every statement that changes a row sets changed_by and change_kind, the trigger stamps the time
+-- and archives the previous version together with the actor that produced it.
+CREATE OR REPLACE FUNCTION update_tender_version()
+RETURNS TRIGGER AS $$
+    BEGIN
+        INSERT INTO tender_history (id, name, description, service_type, status, organization_id, creator_username, version, created_at, updated_at, changed_by, change_kind, changed_at)
+        VALUES (OLD.id, OLD.name, OLD.description, OLD.service_type, OLD.status, OLD.organization_id, OLD.creator_username, OLD.version, OLD.created_at, CURRENT_TIMESTAMP, OLD.changed_by, OLD.change_kind, OLD.changed_at);
+
+        NEW.version := OLD.version + 1;
+        NEW.changed_at := CURRENT_TIMESTAMP;
+
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION update_bid_version()
+RETURNS TRIGGER AS $$
+    BEGIN
+        INSERT INTO bid_history (id, name, description, status, tender_id, author_type, author_id, version, created_at, updated_at, changed_by, change_kind, changed_at)
+        VALUES (OLD.id, OLD.name, OLD.description, OLD.status, OLD.tender_id, OLD.author_type, OLD.author_id, OLD.version, OLD.created_at, CURRENT_TIMESTAMP, OLD.changed_by, OLD.change_kind, OLD.changed_at);
+
+        NEW.version := OLD.version + 1;
+        NEW.changed_at := CURRENT_TIMESTAMP;
+
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
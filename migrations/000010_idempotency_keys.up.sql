@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_key (
+    employee_id   UUID         NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+    key           VARCHAR(255) NOT NULL,
+    request_hash  CHAR(64)     NOT NULL,
+    status_code   INT,
+    content_type  TEXT,
+    response_body BYTEA,
+    created_at    TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at    TIMESTAMP    NOT NULL,
+    PRIMARY KEY (employee_id, key)
+);
+
+CREATE INDEX idx_idempotency_key_expires_at ON idempotency_key (expires_at);
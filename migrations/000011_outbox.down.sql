@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_outbox_event_pending;
+DROP TABLE IF EXISTS outbox_event;
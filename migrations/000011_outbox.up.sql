@@ -0,0 +1,14 @@
+CREATE TABLE outbox_event (
+    id              UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    event_type      VARCHAR(100) NOT NULL,
+    aggregate_type  VARCHAR(50)  NOT NULL,
+    aggregate_id    UUID         NOT NULL,
+    payload         JSONB        NOT NULL,
+    created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error      TEXT,
+    dispatched_at   TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_event_pending ON outbox_event (next_attempt_at) WHERE dispatched_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_webhook_delivery_subscription;
+DROP INDEX IF EXISTS idx_webhook_delivery_pending;
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TYPE IF EXISTS webhook_delivery_status;
+DROP INDEX IF EXISTS idx_webhook_subscription_organization_id;
+DROP TABLE IF EXISTS webhook_subscription;
+ALTER TABLE outbox_event DROP COLUMN IF EXISTS organization_ids;
@@ -0,0 +1,40 @@
+ALTER TABLE outbox_event ADD COLUMN organization_ids UUID[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE webhook_subscription (
+    id              UUID PRIMARY KEY   DEFAULT uuid_generate_v4(),
+    organization_id UUID      NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    url             TEXT      NOT NULL,
+    secret          TEXT      NOT NULL,
+    event_types     TEXT[]    NOT NULL DEFAULT '{}',
+    active          BOOLEAN   NOT NULL DEFAULT TRUE,
+    failure_count   INT       NOT NULL DEFAULT 0,
+    disabled_at     TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_subscription_organization_id ON webhook_subscription (organization_id);
+
+CREATE TYPE webhook_delivery_status AS ENUM (
+    'Pending',
+    'Delivered',
+    'Failed'
+);
+
+CREATE TABLE webhook_delivery (
+    id               UUID PRIMARY KEY                 DEFAULT uuid_generate_v4(),
+    subscription_id  UUID                    NOT NULL REFERENCES webhook_subscription (id) ON DELETE CASCADE,
+    event_id         UUID                    NOT NULL REFERENCES outbox_event (id) ON DELETE CASCADE,
+    status           webhook_delivery_status NOT NULL DEFAULT 'Pending',
+    attempts         INT                     NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMP               NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_status_code INT,
+    last_error       TEXT,
+    created_at       TIMESTAMP               NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at     TIMESTAMP,
+
+    CONSTRAINT unique_subscription_event UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX idx_webhook_delivery_pending ON webhook_delivery (next_attempt_at) WHERE status = 'Pending';
+CREATE INDEX idx_webhook_delivery_subscription ON webhook_delivery (subscription_id, created_at DESC, id DESC);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_review_author_created_at;
+
+ALTER TABLE review DROP CONSTRAINT IF EXISTS review_bid_reviewer_key;
+
+ALTER TABLE review
+    DROP COLUMN IF EXISTS rating,
+    DROP COLUMN IF EXISTS author_id,
+    DROP COLUMN IF EXISTS author_type,
+    DROP COLUMN IF EXISTS tender_id,
+    DROP COLUMN IF EXISTS bid_id;
+
+ALTER TABLE review RENAME COLUMN reviewer_id TO author_username;
+
+CREATE INDEX idx_review_author_created_at ON review (author_username, created_at DESC, id DESC);
@@ -0,0 +1,16 @@
+-- author_username has always held the reviewer id, not a username
+ALTER TABLE review RENAME COLUMN author_username TO reviewer_id;
+
+-- reviews written before the bid was recorded cannot be linked back and keep NULL references
+ALTER TABLE review
+    ADD COLUMN bid_id      UUID REFERENCES bid (id) ON DELETE CASCADE,
+    ADD COLUMN tender_id   UUID REFERENCES tender (id) ON DELETE CASCADE,
+    ADD COLUMN author_type author_type,
+    ADD COLUMN author_id   UUID,
+    ADD COLUMN rating      SMALLINT CHECK (rating BETWEEN 1 AND 5);
+
+-- reviewing the same bid again replaces the earlier review
+ALTER TABLE review ADD CONSTRAINT review_bid_reviewer_key UNIQUE (bid_id, reviewer_id);
+
+DROP INDEX IF EXISTS idx_review_author_created_at;
+CREATE INDEX idx_review_author_created_at ON review (author_type, author_id, created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_decision_bid;
+DROP TABLE IF EXISTS tender_award;
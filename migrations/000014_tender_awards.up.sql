@@ -0,0 +1,20 @@
+CREATE TABLE tender_award (
+    tender_id   UUID PRIMARY KEY     REFERENCES tender (id) ON DELETE CASCADE,
+    bid_id      UUID        NOT NULL REFERENCES bid (id) ON DELETE CASCADE,
+    author_type author_type NOT NULL,
+    author_id   UUID        NOT NULL,
+    awarded_at  TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_tender_award_author ON tender_award (author_type, author_id);
+
+-- wins are known from the decision events recorded so far, earlier wins were never stored
+INSERT INTO tender_award (tender_id, bid_id, author_type, author_id, awarded_at)
+SELECT DISTINCT ON (bid.tender_id) bid.tender_id, bid.id, bid.author_type, bid.author_id, outbox_event.created_at
+FROM outbox_event
+JOIN bid ON bid.id = outbox_event.aggregate_id
+WHERE outbox_event.event_type = 'bid.decision_submitted' AND (outbox_event.payload ->> 'tender_closed')::BOOLEAN
+ORDER BY bid.tender_id, outbox_event.created_at
+ON CONFLICT (tender_id) DO NOTHING;
+
+CREATE INDEX idx_decision_bid ON decision (bid_id);
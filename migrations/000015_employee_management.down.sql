@@ -0,0 +1,2 @@
+ALTER TABLE organization_responsible DROP CONSTRAINT IF EXISTS organization_responsible_key;
+ALTER TABLE employee DROP COLUMN IF EXISTS deactivated_at;
@@ -0,0 +1,8 @@
+-- deactivated employees can not sign in and do not count towards the quorum of their organization
+ALTER TABLE employee ADD COLUMN deactivated_at TIMESTAMP;
+
+DELETE FROM organization_responsible a
+USING organization_responsible b
+WHERE a.organization_id = b.organization_id AND a.user_id = b.user_id AND a.id > b.id;
+
+ALTER TABLE organization_responsible ADD CONSTRAINT organization_responsible_key UNIQUE (organization_id, user_id);
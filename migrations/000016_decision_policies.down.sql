@@ -0,0 +1,5 @@
+ALTER TABLE tender
+    DROP COLUMN IF EXISTS rejection_veto,
+    DROP COLUMN IF EXISTS approver_id,
+    DROP COLUMN IF EXISTS required_approvals,
+    DROP COLUMN IF EXISTS decision_policy;
@@ -0,0 +1,7 @@
+-- the policy type is plain text, the rules behind the types are registered in the application
+-- existing tenders are decided by the majority quorum with a rejection veto
+ALTER TABLE tender
+    ADD COLUMN decision_policy    VARCHAR(50) NOT NULL DEFAULT 'Majority',
+    ADD COLUMN required_approvals INT CHECK (required_approvals > 0),
+    ADD COLUMN approver_id        UUID REFERENCES employee (id) ON DELETE SET NULL,
+    ADD COLUMN rejection_veto     BOOLEAN     NOT NULL DEFAULT TRUE;
@@ -0,0 +1 @@
+-- the backfilled awards can not be told apart from the recorded ones, they are kept
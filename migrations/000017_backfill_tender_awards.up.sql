@@ -0,0 +1,26 @@
+-- tenders closed before decisions were recorded as events got no award in migration 14. The winner of such a tender
+-- is the bid nobody rejected that at least half of the representatives approved, the quorum such tenders were decided by.
+-- The time of the win is unknown
+INSERT INTO tender_award (tender_id, bid_id, author_type, author_id)
+SELECT DISTINCT ON (tender.id) tender.id, bid.id, bid.author_type, bid.author_id
+FROM tender
+JOIN bid ON bid.tender_id = tender.id
+JOIN LATERAL (
+    SELECT COUNT(*) FILTER (WHERE decision.decision = 'Approved') AS approvals,
+           COUNT(*) FILTER (WHERE decision.decision = 'Rejected') AS rejections
+    FROM decision
+    WHERE decision.bid_id = bid.id
+) votes ON TRUE
+JOIN LATERAL (
+    SELECT COUNT(*) AS amount
+    FROM organization_responsible
+    JOIN employee ON employee.id = organization_responsible.user_id
+    WHERE organization_responsible.organization_id = tender.organization_id AND employee.deactivated_at IS NULL
+) representatives ON TRUE
+WHERE tender.status = 'Closed'
+  AND votes.rejections = 0
+  AND votes.approvals > 0
+  AND representatives.amount <= 2 * votes.approvals
+  AND NOT EXISTS (SELECT 1 FROM tender_award WHERE tender_award.tender_id = tender.id)
+ORDER BY tender.id, votes.approvals DESC
+ON CONFLICT (tender_id) DO NOTHING;
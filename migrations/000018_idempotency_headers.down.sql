@@ -0,0 +1,5 @@
+ALTER TABLE idempotency_key ADD COLUMN content_type TEXT;
+
+UPDATE idempotency_key SET content_type = response_headers -> 'Content-Type' ->> 0;
+
+ALTER TABLE idempotency_key DROP COLUMN response_headers;
@@ -0,0 +1,8 @@
+-- a replay carries every header the first response was sent with, not only its content type
+ALTER TABLE idempotency_key ADD COLUMN response_headers JSONB;
+
+UPDATE idempotency_key
+SET response_headers = jsonb_build_object('Content-Type', jsonb_build_array(content_type))
+WHERE content_type IS NOT NULL AND content_type <> '';
+
+ALTER TABLE idempotency_key DROP COLUMN content_type;
@@ -0,0 +1,3 @@
+ALTER TABLE review ALTER COLUMN created_at DROP NOT NULL;
+ALTER TABLE bid ALTER COLUMN created_at DROP NOT NULL;
+ALTER TABLE tender ALTER COLUMN created_at DROP NOT NULL;
@@ -0,0 +1,8 @@
+-- keyset pagination orders by (created_at, id), a row without created_at would fall out of every page
+UPDATE tender SET created_at = COALESCE(changed_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE bid SET created_at = COALESCE(changed_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE review SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+
+ALTER TABLE tender ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE bid ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE review ALTER COLUMN created_at SET NOT NULL;
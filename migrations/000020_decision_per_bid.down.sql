@@ -0,0 +1,7 @@
+-- only the earliest vote of a representative on the tender survives
+DELETE FROM decision d
+USING decision earlier
+WHERE earlier.tender_id = d.tender_id AND earlier.tender_author_id = d.tender_author_id AND earlier.ctid < d.ctid;
+
+ALTER TABLE decision DROP CONSTRAINT unique_bid_author;
+ALTER TABLE decision ADD CONSTRAINT unique_tender_author UNIQUE (tender_id, tender_author_id);
@@ -0,0 +1,3 @@
+-- votes are counted per bid, a representative decides on every bid of the tender
+ALTER TABLE decision DROP CONSTRAINT unique_tender_author;
+ALTER TABLE decision ADD CONSTRAINT unique_bid_author UNIQUE (bid_id, tender_author_id);
@@ -0,0 +1,2 @@
+ALTER TABLE tender DROP CONSTRAINT tender_approver_id_fkey;
+ALTER TABLE tender ADD CONSTRAINT tender_approver_id_fkey FOREIGN KEY (approver_id) REFERENCES employee (id) ON DELETE SET NULL;
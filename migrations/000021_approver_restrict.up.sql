@@ -0,0 +1,3 @@
+-- a SingleApprover tender without its approver could never be decided, the approver is reassigned before the employee goes
+ALTER TABLE tender DROP CONSTRAINT tender_approver_id_fkey;
+ALTER TABLE tender ADD CONSTRAINT tender_approver_id_fkey FOREIGN KEY (approver_id) REFERENCES employee (id) ON DELETE RESTRICT;
@@ -0,0 +1,18 @@
+CREATE OR REPLACE FUNCTION update_tender_version()
+RETURNS TRIGGER AS $$
+    BEGIN
+        INSERT INTO tender_history (id, name, description, service_type, status, organization_id, creator_username, version, created_at, updated_at, changed_by, change_kind, changed_at)
+        VALUES (OLD.id, OLD.name, OLD.description, OLD.service_type, OLD.status, OLD.organization_id, OLD.creator_username, OLD.version, OLD.created_at, CURRENT_TIMESTAMP, OLD.changed_by, OLD.change_kind, OLD.changed_at);
+
+        NEW.version := OLD.version + 1;
+        NEW.changed_at := CURRENT_TIMESTAMP;
+
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE tender_history
+    DROP COLUMN rejection_veto,
+    DROP COLUMN approver_id,
+    DROP COLUMN required_approvals,
+    DROP COLUMN decision_policy;
@@ -0,0 +1,36 @@
+-- the decision policy is versioned with the rest of the tender, so a rollback restores it and a diff reports it.
+-- The policy an archived version was decided by is not known, it is taken to be the current one
+ALTER TABLE tender_history
+    ADD COLUMN decision_policy    VARCHAR(50),
+    ADD COLUMN required_approvals INT,
+    ADD COLUMN approver_id        UUID,
+    ADD COLUMN rejection_veto     BOOLEAN;
+
+UPDATE tender_history th
+SET decision_policy    = t.decision_policy,
+    required_approvals = t.required_approvals,
+    approver_id        = t.approver_id,
+    rejection_veto     = t.rejection_veto
+FROM tender t
+WHERE t.id = th.id;
+
+UPDATE tender_history SET decision_policy = 'Majority', rejection_veto = TRUE WHERE decision_policy IS NULL;
+
+ALTER TABLE tender_history
+    ALTER COLUMN decision_policy SET NOT NULL,
+    ALTER COLUMN rejection_veto SET NOT NULL;
+
+CREATE OR REPLACE FUNCTION update_tender_version()
+RETURNS TRIGGER AS $$
+    BEGIN
+        INSERT INTO tender_history (id, name, description, service_type, status, organization_id, creator_username, version, created_at, updated_at, changed_by, change_kind, changed_at,
+                                    decision_policy, required_approvals, approver_id, rejection_veto)
+        VALUES (OLD.id, OLD.name, OLD.description, OLD.service_type, OLD.status, OLD.organization_id, OLD.creator_username, OLD.version, OLD.created_at, CURRENT_TIMESTAMP, OLD.changed_by, OLD.change_kind, OLD.changed_at,
+                OLD.decision_policy, OLD.required_approvals, OLD.approver_id, OLD.rejection_veto);
+
+        NEW.version := OLD.version + 1;
+        NEW.changed_at := CURRENT_TIMESTAMP;
+
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,3 @@
+-- the request holding the lease is told apart by its token, a request that outlived its lease can not store its response
+-- over the one of the request that took the key over
+ALTER TABLE idempotency_key ADD COLUMN lease_token UUID;
@@ -0,0 +1 @@
+ALTER TABLE review DROP COLUMN updated_at;
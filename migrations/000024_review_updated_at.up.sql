@@ -0,0 +1,2 @@
+-- a replaced review keeps the time it was first written, updated_at records when it was last replaced
+ALTER TABLE review ADD COLUMN updated_at TIMESTAMP;
@@ -0,0 +1,15 @@
+package backoff
+
+import "time"
+
+// Max caps the delay, so a receiver that recovers gets its backlog within the hour.
+const Max = time.Hour
+
+// Delay is the pause after the given failed attempt, counted from 1. It doubles base with every attempt up to Max.
+func Delay(base time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < Max; i++ {
+		delay *= 2
+	}
+	return min(delay, Max)
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+)
+
+type Handler func(ctx context.Context) error
+
+type TxManager interface {
+	// ReadCommitted runs fn in a read committed transaction. The transaction is rolled back when fn returns an error.
+	ReadCommitted(ctx context.Context, fn Handler) error
+}
+
+type txManager struct {
+	db *DB
+}
+
+func (m *txManager) ReadCommitted(ctx context.Context, fn Handler) error {
+	return m.transaction(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, fn)
+}
+
+func (m *txManager) transaction(ctx context.Context, opts pgx.TxOptions, fn Handler) (err error) {
+	// nested calls join the outer transaction
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.pool.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				err = errors.Join(err, rollbackErr)
+			}
+			return
+		}
+
+		err = tx.Commit(ctx)
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, tx))
+}
+
+func NewTxManager(db *DB) TxManager {
+	return &txManager{
+		db: db,
+	}
+}
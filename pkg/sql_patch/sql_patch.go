@@ -39,8 +39,6 @@ func SQLPatches(resource interface{}) SQLPatch {
 		// and make the tag lowercase in the end
 		tag = strings.ToLower(tag)
 
-		sqlPatch.Fields = append(sqlPatch.Fields, fmt.Sprintf("%s = $%d", tag, i))
-
 		var val reflect.Value
 		if fVal.Kind() == reflect.Ptr {
 			val = fVal.Elem()
@@ -48,6 +46,14 @@ func SQLPatches(resource interface{}) SQLPatch {
 			val = fVal
 		}
 
+		// placeholders are numbered by the patched fields only, so the caller can append its own args
+		switch val.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.String, reflect.Bool:
+			sqlPatch.Fields = append(sqlPatch.Fields, fmt.Sprintf("%s = $%d", tag, len(sqlPatch.Args)+1))
+		default:
+			continue
+		}
+
 		switch val.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			sqlPatch.Args = append(sqlPatch.Args, val.Int())